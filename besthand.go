@@ -0,0 +1,168 @@
+package deuces
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MadeHand is the best five card hand that can be made from a hand and board.
+type MadeHand struct {
+	// Cards holds the five cards in canonical order: grouped by how many of
+	// each rank there are, larger groups and higher ranks first. Straights
+	// run from the top card down, with the ace last in a five high straight.
+	Cards       []Card
+	Rank        HandRank
+	Description string
+}
+
+// String returns the description of the hand.
+func (m *MadeHand) String() string {
+	return m.Description
+}
+
+var (
+	rankNames = [...]string{
+		"Two", "Three", "Four", "Five", "Six", "Seven", "Eight",
+		"Nine", "Ten", "Jack", "Queen", "King", "Ace",
+	}
+	rankPluralNames = [...]string{
+		"Twos", "Threes", "Fours", "Fives", "Sixes", "Sevens", "Eights",
+		"Nines", "Tens", "Jacks", "Queens", "Kings", "Aces",
+	}
+)
+
+// BestHand returns the five cards that make the best hand out of hand and
+// board, along with their rank and a description such as
+// "Full House, Kings full of Sevens". When several sets of cards share the
+// best rank, the one using the earliest cards of hand and board is returned.
+// It validates the cards like EvaluateChecked.
+func (e *Evaluator) BestHand(hand []Card, board []Card) (*MadeHand, error) {
+	cards, rank, description, err := e.bestHand(hand, board)
+	if err != nil {
+		return nil, err
+	}
+	return &MadeHand{Cards: cards, Rank: HandRank(rank), Description: description}, nil
+}
+
+// bestHand does the work of BestHand, returning the cards in canonical order
+// with their rank on the evaluator's scale and their description.
+func (e *Evaluator) bestHand(hand []Card, board []Card) ([]Card, int, string, error) {
+	n := len(hand) + len(board)
+	if n < 5 || n > 7 {
+		return nil, 0, "", &CardCountError{Count: n}
+	}
+	if err := e.checkCards(hand, board); err != nil {
+		return nil, 0, "", err
+	}
+
+	var cards [7]Card
+	copy(cards[:], hand)
+	copy(cards[len(hand):], board)
+
+	subsets := [][5]uint8{{0, 1, 2, 3, 4}}
+	switch n {
+	case 6:
+		subsets = fiveOfSix
+	case 7:
+		subsets = fiveOfSeven
+	}
+
+	var best [5]uint8
+	bestRank := MaxHighCard + 1
+	for _, s := range subsets {
+		rank := e.lookupTable.lookup(cards[s[0]], cards[s[1]], cards[s[2]], cards[s[3]], cards[s[4]])
+		if rank < bestRank {
+			bestRank = rank
+			best = s
+		}
+	}
+
+	made := make([]Card, 5)
+	for i, idx := range best {
+		made[i] = cards[idx]
+	}
+	class := HandClass(e.GetRankClass(bestRank))
+	wheelTop := 3 // the five of 5-4-3-2-A
+	if e.shortDeck {
+		wheelTop = shortDeckLowestRank + 3 // the nine of 9-8-7-6-A
+	}
+	sortMadeHand(made, class, wheelTop)
+	return made, bestRank, describeMadeHand(made, bestRank, class), nil
+}
+
+// sortMadeHand puts five cards of the given class in canonical order, given
+// the top rank of the straight where the ace plays low.
+func sortMadeHand(cards []Card, class HandClass, wheelTop int) {
+	var counts [13]int
+	for _, c := range cards {
+		counts[c.GetRankInt()]++
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		ri, rj := cards[i].GetRankInt(), cards[j].GetRankInt()
+		if counts[ri] != counts[rj] {
+			return counts[ri] > counts[rj]
+		}
+		if ri != rj {
+			return ri > rj
+		}
+		return cards[i].index() < cards[j].index()
+	})
+
+	// the ace plays low in the lowest straight
+	if (class == Straight || class == StraightFlush) && cards[0].GetRankInt() == 12 && cards[1].GetRankInt() == wheelTop {
+		ace := cards[0]
+		copy(cards, cards[1:])
+		cards[4] = ace
+	}
+}
+
+// describeMadeHand names five cards of the given rank and class in canonical
+// order.
+func describeMadeHand(cards []Card, rank int, class HandClass) string {
+	r := make([]int, len(cards))
+	for i, c := range cards {
+		r[i] = c.GetRankInt()
+	}
+
+	switch class {
+	case StraightFlush:
+		if rank == 1 {
+			return "Royal Flush"
+		}
+		return fmt.Sprintf("Straight Flush, %s high", rankNames[r[0]])
+	case FourOfAKind:
+		return fmt.Sprintf("Four of a Kind, %s, %s", rankPluralNames[r[0]], kickers(r[4:]))
+	case FullHouse:
+		return fmt.Sprintf("Full House, %s full of %s", rankPluralNames[r[0]], rankPluralNames[r[3]])
+	case Flush:
+		return fmt.Sprintf("Flush, %s", rankChars(r))
+	case Straight:
+		return fmt.Sprintf("Straight, %s high", rankNames[r[0]])
+	case ThreeOfAKind:
+		return fmt.Sprintf("Three of a Kind, %s, %s", rankPluralNames[r[0]], kickers(r[3:]))
+	case TwoPair:
+		return fmt.Sprintf("Two Pair, %s and %s, %s", rankPluralNames[r[0]], rankPluralNames[r[2]], kickers(r[4:]))
+	case Pair:
+		return fmt.Sprintf("Pair of %s, %s", rankPluralNames[r[0]], kickers(r[2:]))
+	default:
+		return fmt.Sprintf("High Card, %s, %s", rankNames[r[0]], kickers(r[1:]))
+	}
+}
+
+// kickers formats ranks as "K-Q-9 kickers", or "K kicker" for a single rank.
+func kickers(ranks []int) string {
+	if len(ranks) == 1 {
+		return rankChars(ranks) + " kicker"
+	}
+	return rankChars(ranks) + " kickers"
+}
+
+// rankChars joins rank characters with dashes, such as "A-K-9-7-4".
+func rankChars(ranks []int) string {
+	chars := make([]string, len(ranks))
+	for i, r := range ranks {
+		chars[i] = string(StrRanks[r])
+	}
+	return strings.Join(chars, "-")
+}
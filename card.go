@@ -2,6 +2,7 @@ package deuces
 
 import (
 	"fmt"
+	"math/bits"
 	"strings"
 )
 
@@ -71,6 +72,27 @@ func NewCard(s string) (Card, error) {
 	return Card(bitrank | suit | rank | rankPrime), nil
 }
 
+// String returns the card in the notation accepted by NewCard, such as "As".
+func (c Card) String() string {
+	if !c.IsValid() {
+		return fmt.Sprintf("Card(%d)", int32(c))
+	}
+	return string(StrRanks[c.GetRankInt()]) + string(IntSuitToCharSuit[c.GetSuitInt()])
+}
+
+// IsValid reports whether c is one of the 52 cards returned by NewCard.
+func (c Card) IsValid() bool {
+	if c.GetRankInt() >= len(StrRanks) {
+		return false
+	}
+	switch c.GetSuitInt() {
+	case 1, 2, 4, 8:
+	default:
+		return false
+	}
+	return c == fullDeck[c.index()]
+}
+
 // IntToPrettyStr converts a card integer to a pretty string.
 func (c Card) IntToPrettyStr() string {
 	rankInt := c.GetRankInt()
@@ -97,3 +119,8 @@ func (c Card) GetBitrankInt() int {
 func (c Card) GetPrime() int {
 	return int(c) & 0x3F
 }
+
+// index returns the card's position in an unshuffled deck, from 0 to 51.
+func (c Card) index() int {
+	return c.GetRankInt()*4 + bits.TrailingZeros(uint(c.GetSuitInt()))
+}
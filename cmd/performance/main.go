@@ -84,10 +84,13 @@ func main() {
 		}
 
 		// Evaluate the hand
-		score := evaluator.Evaluate(hand, board)
+		score, err := evaluator.EvaluateChecked(hand, board)
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		// Add the score to the results slice
-		results = append(results, score)
+		results = append(results, int(score))
 	}
 
 	// Stop the timer
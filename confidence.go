@@ -0,0 +1,68 @@
+package deuces
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultConfidence is the confidence level used by SimulationOptions when
+// Precision is set and Confidence is not.
+const DefaultConfidence = 0.95
+
+// ConfidenceInterval returns the interval around a sampled probability or
+// equity that holds its true value with the given confidence, such as 0.95,
+// using the normal approximation. The interval is clipped to [0, 1].
+func ConfidenceInterval(estimate, stdErr, confidence float64) (low, high float64) {
+	margin := zScore(confidence) * stdErr
+	return max(estimate-margin, 0), min(estimate+margin, 1)
+}
+
+// zScore returns the number of standard errors either side of the mean that
+// a normal distribution holds with the given confidence.
+func zScore(confidence float64) float64 {
+	return math.Sqrt2 * math.Erfinv(confidence)
+}
+
+// proportionStdErr returns the standard error of a probability p estimated
+// from n samples.
+func proportionStdErr(p float64, n int) float64 {
+	return math.Sqrt(p * (1 - p) / float64(n))
+}
+
+// meanStdErr returns the standard error of the mean of n samples, given the
+// mean of their squares.
+func meanStdErr(mean, meanSq float64, n int) float64 {
+	variance := max(meanSq-mean*mean, 0)
+	return math.Sqrt(variance / float64(n))
+}
+
+// precise reports whether n samples with the given largest standard error
+// are enough to meet o.Precision. It is always false if Precision is unset.
+func (o SimulationOptions) precise(stdErr float64, n int) bool {
+	confidence := o.Confidence
+	if confidence == 0 {
+		confidence = DefaultConfidence
+	}
+	return o.Precision > 0 && n >= workerBatch && zScore(confidence)*stdErr <= o.Precision
+}
+
+// validateIterations checks a simulation's iteration count, which is a
+// budget rather than a fixed count when o.Precision is set.
+func (o SimulationOptions) validateIterations(iterations int) error {
+	if o.Precision < 0 || o.Precision >= 1 {
+		return fmt.Errorf("precision must be in [0, 1), got %g", o.Precision)
+	}
+	if o.Confidence < 0 || o.Confidence >= 1 {
+		return fmt.Errorf("confidence must be in (0, 1), or 0 for the default, got %g", o.Confidence)
+	}
+	if o.Precision > 0 {
+		if iterations < 1 {
+			return fmt.Errorf("iteration budget must be positive, got %d", iterations)
+		}
+		return nil
+	}
+	if iterations < MinIterations {
+		return fmt.Errorf("iterations should be at least %d to ensure reliability, got %d", MinIterations, iterations)
+	}
+	return nil
+}
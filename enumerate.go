@@ -0,0 +1,213 @@
+package deuces
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ExactEnumerationLimit is the largest number of showdowns that
+// CalculateWinProbability enumerates exactly before it falls back to sampling.
+const ExactEnumerationLimit = 25_000_000
+
+// EnumerateWinProbability computes the exact probability of winning against
+// numOpponents random hands. It walks every way to complete the board and
+// every set of opponent hole cards from the remaining deck, so it is only
+// practical when few cards are unknown, such as on the turn or river, or on
+// the flop heads-up. CalculateWinProbability picks between this and sampling.
+// Dead cards, such as mucked or exposed cards, are never dealt.
+func EnumerateWinProbability(hand []Card, board []Card, dead []Card, numOpponents int) (*HandResult, error) {
+	if err := validateWinProbabilityInput(hand, board, dead, numOpponents); err != nil {
+		return nil, err
+	}
+	return enumerateWinProbability(hand, board, dead, numOpponents), nil
+}
+
+// CalculateWinProbability computes the probability of winning against
+// numOpponents random hands, enumerating every showdown when there are at
+// most ExactEnumerationLimit of them and otherwise running a Monte Carlo
+// simulation of the given number of iterations. HandResult.Exact reports
+// which one was used.
+func CalculateWinProbability(hand []Card, board []Card, dead []Card, numOpponents int, iterations int) (*HandResult, error) {
+	if err := validateWinProbabilityInput(hand, board, dead, numOpponents); err != nil {
+		return nil, err
+	}
+	unknown := len(fullDeck) - len(hand) - len(board) - len(dead)
+	if countShowdowns(unknown, 5-len(board), numOpponents) <= ExactEnumerationLimit {
+		return enumerateWinProbability(hand, board, dead, numOpponents), nil
+	}
+	return EstimateWinProbabilityWithOptions(context.Background(), hand, board, dead, numOpponents, iterations, SimulationOptions{})
+}
+
+// enumerateWinProbability does the work of EnumerateWinProbability on
+// validated input. Boards are shared out between one worker per CPU.
+func enumerateWinProbability(hand []Card, board []Card, dead []Card, numOpponents int) *HandResult {
+	evaluator := NewEvaluator()
+
+	stub := &Deck{Cards: GetFullDeck()}
+	stub.Remove(hand...)
+	stub.Remove(board...)
+	stub.Remove(dead...)
+
+	boards := completeBoards(board, stub.Cards)
+
+	tallies := make([]showdownTally, runtime.NumCPU())
+	remaining := make([][]Card, runtime.NumCPU())
+	holdings := make([][]rankedHolding, runtime.NumCPU())
+
+	parallelFor(len(boards), func(worker, i int) {
+		tally := &tallies[worker]
+		fullBoard := boards[i][:]
+		heroRank := evaluator.Evaluate(hand, fullBoard)
+		if numOpponents == 0 {
+			tally.record(false, 0)
+			return
+		}
+
+		remaining[worker] = remaining[worker][:0]
+		for _, c := range stub.Cards {
+			if !containsCard(fullBoard, c) {
+				remaining[worker] = append(remaining[worker], c)
+			}
+		}
+		holdings[worker] = rankHoldings(evaluator, remaining[worker], fullBoard, holdings[worker][:0])
+		enumerateOpponents(holdings[worker], 0, 0, numOpponents, heroRank, false, 0, tally)
+	})
+
+	var total showdownTally
+	for _, tally := range tallies {
+		total.add(tally)
+	}
+	return total.result(true)
+}
+
+// parallelFor calls fn for every i below n from one goroutine per CPU.
+// Workers are numbered from 0 to runtime.NumCPU()-1 so fn can keep
+// per-worker state.
+func parallelFor(n int, fn func(worker, i int)) {
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= n {
+					return
+				}
+				fn(worker, i)
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// rankedHolding is a pair of hole cards with its rank on a complete board.
+// mask has one bit per card, indexed by position in the remaining deck.
+type rankedHolding struct {
+	mask uint64
+	rank int
+}
+
+// rankHoldings ranks every pair of the remaining cards on the board.
+func rankHoldings(e *Evaluator, remaining []Card, board []Card, holdings []rankedHolding) []rankedHolding {
+	var pair [2]Card
+	for i := 0; i < len(remaining); i++ {
+		for j := i + 1; j < len(remaining); j++ {
+			pair[0], pair[1] = remaining[i], remaining[j]
+			holdings = append(holdings, rankedHolding{
+				mask: 1<<uint(i) | 1<<uint(j),
+				rank: e.Evaluate(pair[:], board),
+			})
+		}
+	}
+	return holdings
+}
+
+// enumerateOpponents records a showdown for every set of count disjoint
+// holdings taken in order from holdings[start:].
+func enumerateOpponents(holdings []rankedHolding, start int, used uint64, count int, heroRank int, lost bool, tied int, tally *showdownTally) {
+	if count == 0 {
+		tally.record(lost, tied)
+		return
+	}
+	for i := start; i <= len(holdings)-count; i++ {
+		h := holdings[i]
+		if h.mask&used != 0 {
+			continue
+		}
+		t := tied
+		if h.rank == heroRank {
+			t++
+		}
+		enumerateOpponents(holdings, i+1, used|h.mask, count-1, heroRank, lost || h.rank < heroRank, t, tally)
+	}
+}
+
+// completeBoards returns every five card board that extends board with cards
+// from stub.
+func completeBoards(board []Card, stub []Card) [][5]Card {
+	missing := 5 - len(board)
+	var base [5]Card
+	copy(base[:], board)
+
+	boards := make([][5]Card, 0, int(binomial(len(stub), missing)))
+	indices := make([]int, missing)
+	for i := range indices {
+		indices[i] = i
+	}
+	for {
+		b := base
+		for i, idx := range indices {
+			b[len(board)+i] = stub[idx]
+		}
+		boards = append(boards, b)
+
+		i := missing - 1
+		for i >= 0 && indices[i] == len(stub)-missing+i {
+			i--
+		}
+		if i < 0 {
+			return boards
+		}
+		indices[i]++
+		for j := i + 1; j < missing; j++ {
+			indices[j] = indices[j-1] + 1
+		}
+	}
+}
+
+// countShowdowns returns the number of distinct showdowns when missing board
+// cards and numOpponents hands are dealt from unknown cards.
+func countShowdowns(unknown, missing, numOpponents int) float64 {
+	count := binomial(unknown, missing)
+	remaining := unknown - missing
+	for i := 0; i < numOpponents; i++ {
+		count *= binomial(remaining-2*i, 2) / float64(i+1)
+	}
+	return count
+}
+
+// binomial returns n choose k as a float64, which is exact for the sizes
+// that come up with a single deck.
+func binomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1.0
+	for i := 0; i < k; i++ {
+		result = result * float64(n-i) / float64(i+1)
+	}
+	return result
+}
+
+func containsCard(cards []Card, card Card) bool {
+	for _, c := range cards {
+		if c == card {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,296 @@
+package deuces
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+)
+
+// PlayerEquity is one player's share of the showdowns in an equity calculation.
+type PlayerEquity struct {
+	WinProbability float64 // Probability of winning the whole pot
+	TieProbability float64 // Probability of splitting the pot with other players
+	Equity         float64 // Expected share of the pot, counting a k-way split as 1/k
+	WinStdErr      float64 // Standard error of WinProbability, 0 when exact
+	TieStdErr      float64 // Standard error of TieProbability, 0 when exact
+	EquityStdErr   float64 // Standard error of Equity, 0 when exact
+}
+
+// EquityResult holds the equity of every player in a showdown, in the order
+// their hands were given.
+type EquityResult struct {
+	Players         []PlayerEquity
+	TotalIterations int  // Total number of simulations run, or of showdowns enumerated
+	Exact           bool // Whether every showdown was enumerated rather than sampled
+}
+
+// maxStdErr returns the largest standard error of any player's estimates.
+func (er EquityResult) maxStdErr() float64 {
+	largest := 0.0
+	for _, p := range er.Players {
+		largest = max(largest, p.WinStdErr, p.TieStdErr, p.EquityStdErr)
+	}
+	return largest
+}
+
+// String provides a formatted string representation of the results
+func (er EquityResult) String() string {
+	var sb strings.Builder
+	for i, p := range er.Players {
+		fmt.Fprintf(&sb, "Player %d: Equity: %.2f%%, Win: %.2f%%, Tie: %.2f%%\n",
+			i+1, p.Equity*100, p.WinProbability*100, p.TieProbability*100)
+	}
+	if er.Exact {
+		fmt.Fprintf(&sb, "(exact, from %d showdowns)", er.TotalIterations)
+	} else {
+		fmt.Fprintf(&sb, "(from %d iterations)", er.TotalIterations)
+	}
+	return sb.String()
+}
+
+// equityTally counts showdown outcomes for every player. Each showdown has a
+// weight, which is 1 unless the hands come from weighted ranges.
+type equityTally struct {
+	wins     []float64
+	ties     []float64
+	shares   []float64
+	sharesSq []float64 // for the standard error of the equity
+	weight   float64
+	total    int
+}
+
+func newEquityTally(players int) equityTally {
+	return equityTally{
+		wins:     make([]float64, players),
+		ties:     make([]float64, players),
+		shares:   make([]float64, players),
+		sharesSq: make([]float64, players),
+	}
+}
+
+// recordShares adds a showdown of the given weight given every player's
+// share of the pot. Only a player taking the whole pot wins it.
+func (t *equityTally) recordShares(shares []float64, weight float64) {
+	for i, share := range shares {
+		switch {
+		case share == 1:
+			t.wins[i] += weight
+		case share > 0:
+			t.ties[i] += weight
+		}
+		t.shares[i] += weight * share
+		t.sharesSq[i] += weight * share * share
+	}
+	t.weight += weight
+	t.total++
+}
+
+// record adds a showdown of the given weight given every player's rank.
+func (t *equityTally) record(ranks []int, weight float64) {
+	best, winners := MaxHighCard+1, 0
+	for _, rank := range ranks {
+		if rank < best {
+			best, winners = rank, 1
+		} else if rank == best {
+			winners++
+		}
+	}
+
+	share := weight / float64(winners)
+	for i, rank := range ranks {
+		if rank != best {
+			continue
+		}
+		if winners == 1 {
+			t.wins[i] += weight
+		} else {
+			t.ties[i] += weight
+		}
+		t.shares[i] += share
+		t.sharesSq[i] += share / float64(winners)
+	}
+	t.weight += weight
+	t.total++
+}
+
+func (t *equityTally) add(other equityTally) {
+	for i := range t.wins {
+		t.wins[i] += other.wins[i]
+		t.ties[i] += other.ties[i]
+		t.shares[i] += other.shares[i]
+		t.sharesSq[i] += other.sharesSq[i]
+	}
+	t.weight += other.weight
+	t.total += other.total
+}
+
+// stdErr returns the largest standard error of any player's estimates.
+func (t equityTally) stdErr() float64 {
+	return t.result(false).maxStdErr()
+}
+
+func (t equityTally) count() int {
+	return t.total
+}
+
+// reset empties the tally so it can be reused.
+func (t *equityTally) reset() {
+	clear(t.wins)
+	clear(t.ties)
+	clear(t.shares)
+	clear(t.sharesSq)
+	t.weight, t.total = 0, 0
+}
+
+// result converts the tally into probabilities, which are all zero if no
+// showdown was recorded.
+func (t equityTally) result(exact bool) *EquityResult {
+	players := make([]PlayerEquity, len(t.wins))
+	for i := range players {
+		if t.weight == 0 {
+			continue
+		}
+		p := PlayerEquity{
+			WinProbability: t.wins[i] / t.weight,
+			TieProbability: t.ties[i] / t.weight,
+			Equity:         t.shares[i] / t.weight,
+		}
+		if !exact {
+			p.WinStdErr = proportionStdErr(p.WinProbability, t.total)
+			p.TieStdErr = proportionStdErr(p.TieProbability, t.total)
+			p.EquityStdErr = meanStdErr(p.Equity, t.sharesSq[i]/t.weight, t.total)
+		}
+		players[i] = p
+	}
+	return &EquityResult{Players: players, TotalIterations: t.total, Exact: exact}
+}
+
+// EnumerateEquity computes the exact all-in equity of players whose hole
+// cards are all known, such as AsKs against QdQc against 7h7d, by dealing
+// every possible completion of the board. Dead cards, such as mucked or
+// exposed cards, are never dealt.
+func EnumerateEquity(hands [][]Card, board []Card, dead []Card) (*EquityResult, error) {
+	if err := validateEquityInput(Holdem, hands, board, dead); err != nil {
+		return nil, err
+	}
+
+	evaluator := NewEvaluator()
+	stub := knownHandsStub(hands, board, dead)
+	boards := completeBoards(board, stub.Cards)
+
+	tallies := make([]equityTally, runtime.NumCPU())
+	ranks := make([][]int, runtime.NumCPU())
+	for w := range tallies {
+		tallies[w] = newEquityTally(len(hands))
+		ranks[w] = make([]int, len(hands))
+	}
+
+	parallelFor(len(boards), func(worker, i int) {
+		fullBoard := boards[i][:]
+		for p, hand := range hands {
+			ranks[worker][p] = evaluator.Evaluate(hand, fullBoard)
+		}
+		tallies[worker].record(ranks[worker], 1)
+	})
+
+	total := newEquityTally(len(hands))
+	for _, tally := range tallies {
+		total.add(tally)
+	}
+	return total.result(true), nil
+}
+
+// EstimateEquity estimates the all-in equity of players whose hole cards are
+// all known using Monte Carlo simulation over the remaining board cards.
+// Dead cards, such as mucked or exposed cards, are never dealt.
+func EstimateEquity(hands [][]Card, board []Card, dead []Card, iterations int) (*EquityResult, error) {
+	return EstimateEquityWithOptions(context.Background(), hands, board, dead, iterations, SimulationOptions{})
+}
+
+// EstimateEquityWithOptions is like EstimateEquity, with the seed, workers,
+// random source, target precision and game set by opts. It stops promptly once ctx is done, returning the results
+// of the iterations run so far together with ctx.Err(). In a game that splits
+// the pot with the low, a player wins only by scooping, and ties whenever
+// they take part of the pot.
+func EstimateEquityWithOptions(ctx context.Context, hands [][]Card, board []Card, dead []Card, iterations int, opts SimulationOptions) (*EquityResult, error) {
+	if err := validateEquityInput(opts.Game, hands, board, dead); err != nil {
+		return nil, err
+	}
+	if err := opts.validateIterations(iterations); err != nil {
+		return nil, err
+	}
+
+	evaluator := opts.Game.newEvaluator()
+
+	samplers := make([]*sampler, opts.workers())
+	for w := range samplers {
+		samplers[w] = newDeckSampler(opts.Game.deck(), append(append([][]Card{}, hands...), board, dead)...)
+	}
+
+	newTally := func() equityTally { return newEquityTally(len(hands)) }
+	total, err := simulateBatches(ctx, iterations, opts, newTally, func(worker int, rng *rand.Rand, workerIter int, batch *equityTally) {
+		deck := samplers[worker]
+		deck.reset()
+
+		ranks := make([]int, len(hands))
+		lows := make([]int, len(hands))
+		shares := make([]float64, len(hands))
+		var currentBoard [5]Card
+		copy(currentBoard[:], board)
+		for j := 0; j < workerIter; j++ {
+			deck.newDeal()
+			copy(currentBoard[len(board):], deck.draw(rng, 5-len(board)))
+
+			for p, hand := range hands {
+				ranks[p] = opts.Game.evaluate(evaluator, hand, currentBoard[:])
+			}
+			if !opts.Game.splitsPot() {
+				batch.record(ranks, 1)
+				continue
+			}
+			for p, hand := range hands {
+				lows[p] = opts.Game.evaluateLow(evaluator, hand, currentBoard[:])
+			}
+			splitHiLo(ranks, lows, shares)
+			batch.recordShares(shares, 1)
+		}
+	}, nil)
+	return total.result(false), err
+}
+
+// validateEquityInput checks the arguments shared by the functions that
+// compute the equity of known hands of the given game, which must all hold
+// the same number of cards.
+func validateEquityInput(game Game, hands [][]Card, board []Card, dead []Card) error {
+	if len(hands) < 2 || len(hands) > MaxOpponents+1 {
+		return fmt.Errorf("number of players must be between 2 and %d, got %d", MaxOpponents+1, len(hands))
+	}
+	for i, hand := range hands {
+		if err := game.checkHoleCards(len(hand)); err != nil {
+			return fmt.Errorf("hand %d: %w", i+1, err)
+		}
+		if i > 0 && len(hand) != len(hands[0]) {
+			return fmt.Errorf("hand %d must contain %d cards like the first, got %d", i+1, len(hands[0]), len(hand))
+		}
+	}
+	if len(board) > 5 {
+		return fmt.Errorf("board must contain between 0 and 5 cards, got %d", len(board))
+	}
+	if err := checkCards(append(append([][]Card{}, hands...), board, dead)...); err != nil {
+		return err
+	}
+	return game.checkDeck(5-len(board), append(append([][]Card{}, hands...), board, dead)...)
+}
+
+// knownHandsStub returns a deck without the given hands, board and dead cards.
+func knownHandsStub(hands [][]Card, board []Card, dead []Card) *Deck {
+	stub := &Deck{Cards: GetFullDeck()}
+	for _, hand := range hands {
+		stub.Remove(hand...)
+	}
+	stub.Remove(board...)
+	stub.Remove(dead...)
+	return stub
+}
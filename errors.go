@@ -0,0 +1,66 @@
+package deuces
+
+import (
+	"fmt"
+)
+
+// CardCountError reports a hand and board that together do not hold five to
+// seven cards.
+type CardCountError struct {
+	Count int
+}
+
+func (e *CardCountError) Error() string {
+	return fmt.Sprintf("hand and board must contain between 5 and 7 cards, got %d", e.Count)
+}
+
+// DuplicateCardError reports a card that appears more than once.
+type DuplicateCardError struct {
+	Card Card
+}
+
+func (e *DuplicateCardError) Error() string {
+	return fmt.Sprintf("duplicate card: %s", e.Card)
+}
+
+// InvalidCardError reports a Card value that does not encode a playing card.
+type InvalidCardError struct {
+	Card Card
+}
+
+func (e *InvalidCardError) Error() string {
+	return fmt.Sprintf("invalid card value: %d", int32(e.Card))
+}
+
+// checkDealable returns an error unless the deck still holds enough cards to
+// deal needed more once known cards are out of it.
+func checkDealable(known, needed int) error {
+	return checkDealableFrom(len(fullDeck), known, needed)
+}
+
+// checkDealableFrom is like checkDealable for a deck of the given size.
+func checkDealableFrom(deckSize, known, needed int) error {
+	if left := deckSize - known; left < needed {
+		return fmt.Errorf("not enough cards left to deal: need %d, %d left", needed, left)
+	}
+	return nil
+}
+
+// checkCards returns an *InvalidCardError or *DuplicateCardError for the first
+// malformed or repeated card across all of the given sets.
+func checkCards(sets ...[]Card) error {
+	var seen uint64
+	for _, cards := range sets {
+		for _, c := range cards {
+			if !c.IsValid() {
+				return &InvalidCardError{Card: c}
+			}
+			bit := uint64(1) << c.index()
+			if seen&bit != 0 {
+				return &DuplicateCardError{Card: c}
+			}
+			seen |= bit
+		}
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package deuces
 // Evaluator evaluates hand strengths.
 type Evaluator struct {
 	lookupTable *LookupTable
+	stateTable  *StateTable // optional, see NewStateTableEvaluator
+	shortDeck   bool        // ranks on the short deck scale, see newShortDeckEvaluator
 }
 
 // NewEvaluator creates a new Evaluator.
@@ -13,48 +15,69 @@ func NewEvaluator() *Evaluator {
 }
 
 // Evaluate evaluates a hand of cards.
+// The hand and board are copied, never appended to, so the caller's slices are
+// left untouched and evaluation does not allocate.
 func (e *Evaluator) Evaluate(hand []Card, board []Card) int {
-	allCards := append(hand, board...)
+	n := len(hand) + len(board)
+	if n < 5 || n > 7 {
+		return -1 // Should not happen with valid input
+	}
+
+	if e.stateTable != nil {
+		return e.stateTable.Evaluate(hand, board)
+	}
 
-	switch len(allCards) {
+	var cards [7]Card
+	copy(cards[:], hand)
+	copy(cards[len(hand):], board)
+
+	switch n {
 	case 5:
-		return e.evaluateFive(allCards)
+		return e.lookupTable.lookup(cards[0], cards[1], cards[2], cards[3], cards[4])
 	case 6:
-		return e.evaluateBestFiveOutOfN(allCards)
-	case 7:
-		return e.evaluateBestFiveOutOfN(allCards)
+		return e.evaluateBestFive(&cards, fiveOfSix)
 	default:
-		return -1 // Should not happen with valid input
+		return e.evaluateBestFive(&cards, fiveOfSeven)
 	}
 }
 
-func (e *Evaluator) evaluateFive(cards []Card) int {
-	// if flush
-	if (cards[0]&cards[1]&cards[2]&cards[3]&cards[4])&0xF000 != 0 {
-		handOR := (cards[0] | cards[1] | cards[2] | cards[3] | cards[4]) >> 16
-		prime := primeProductFromRankbits(int(handOR))
+// EvaluateChecked evaluates a hand like Evaluate, but validates the cards first.
+// It returns a *CardCountError unless hand and board hold five to seven cards
+// together, an *InvalidCardError for a value that is not a card, and a
+// *DuplicateCardError for a card that appears twice.
+func (e *Evaluator) EvaluateChecked(hand []Card, board []Card) (HandRank, error) {
+	if n := len(hand) + len(board); n < 5 || n > 7 {
+		return 0, &CardCountError{Count: n}
+	}
+	if err := e.checkCards(hand, board); err != nil {
+		return 0, err
+	}
+	return HandRank(e.Evaluate(hand, board)), nil
+}
 
-		// Check for straight flush
-		if value, ok := e.lookupTable.FlushLookup[prime]; ok {
-			return value
+// checkCards is checkCards for the cards of the evaluator's deck, which
+// holds no card below a six for a short deck evaluator.
+func (e *Evaluator) checkCards(hand []Card, board []Card) error {
+	if err := checkCards(hand, board); err != nil {
+		return err
+	}
+	if e.shortDeck {
+		for _, cards := range [][]Card{hand, board} {
+			for _, c := range cards {
+				if c.GetRankInt() < shortDeckLowestRank {
+					return &InvalidCardError{Card: c}
+				}
+			}
 		}
-
-		// It's a regular flush, use the unsuited lookup
-		prime = primeProductFromHand(cards)
-		return e.lookupTable.UnsuitedLookup[prime]
 	}
-
-	// otherwise
-	prime := primeProductFromHand(cards)
-	return e.lookupTable.UnsuitedLookup[prime]
+	return nil
 }
 
-func (e *Evaluator) evaluateBestFiveOutOfN(cards []Card) int {
+// evaluateBestFive returns the best rank among the given five card subsets.
+func (e *Evaluator) evaluateBestFive(cards *[7]Card, subsets [][5]uint8) int {
 	minimum := MaxHighCard
-
-	combinations := combinationsCards(cards, 5)
-	for _, combo := range combinations {
-		score := e.evaluateFive(combo)
+	for _, s := range subsets {
+		score := e.lookupTable.lookup(cards[s[0]], cards[s[1]], cards[s[2]], cards[s[3]], cards[s[4]])
 		if score < minimum {
 			minimum = score
 		}
@@ -63,7 +86,11 @@ func (e *Evaluator) evaluateBestFiveOutOfN(cards []Card) int {
 }
 
 // GetRankClass returns the class of hand given the hand rank.
+// HandRank.Class does the same without an Evaluator.
 func (e *Evaluator) GetRankClass(handRank int) int {
+	if e.shortDeck {
+		return shortDeckRankClass(handRank)
+	}
 	if handRank >= 0 && handRank <= MaxStraightFlush {
 		return MaxToRankClass[MaxStraightFlush]
 	} else if handRank <= MaxFourOfAKind {
@@ -88,6 +115,7 @@ func (e *Evaluator) GetRankClass(handRank int) int {
 }
 
 // ClassToString converts the integer class hand score into a human-readable string.
+// HandClass.String does the same without an Evaluator.
 func (e *Evaluator) ClassToString(classInt int) string {
 	return RankClassToString[classInt]
 }
@@ -99,59 +127,22 @@ func (e *Evaluator) GetFiveCardRankPercentage(handRank int) float64 {
 
 // Helper functions
 
-func primeProductFromHand(cards []Card) int {
-	product := 1
-	for _, card := range cards {
-		product *= card.GetPrime()
-	}
-	return product
-}
-
-// combinationsCards generates all combinations of k elements from arr.
-func combinationsCards(arr []Card, k int) [][]Card {
-	n := len(arr)
-	if k < 0 || k > n {
-		return nil
-	}
-	if k == 0 {
-		return [][]Card{{}}
-	}
-	if k == n {
-		return [][]Card{arr}
-	}
-
-	// Calculate the number of combinations (nCk)
-	numCombinations := 1
-	for i := 0; i < k; i++ {
-		numCombinations = numCombinations * (n - i) / (i + 1)
-	}
+// fiveOfSix and fiveOfSeven hold the indices of every five card subset of
+// six and seven cards.
+var (
+	fiveOfSix   = fiveCardSubsets(6)
+	fiveOfSeven = fiveCardSubsets(7)
+)
 
-	result := make([][]Card, 0, numCombinations)
-	indices := make([]int, k)
+func fiveCardSubsets(n int) [][5]uint8 {
+	indices := make([]int, n)
 	for i := range indices {
 		indices[i] = i
 	}
 
-	for {
-		combination := make([]Card, k)
-		for i, idx := range indices {
-			combination[i] = arr[idx]
-		}
-		result = append(result, combination)
-
-		i := k - 1
-		for i >= 0 && indices[i] == n-k+i {
-			i--
-		}
-
-		if i < 0 {
-			break
-		}
-
-		indices[i]++
-		for j := i + 1; j < k; j++ {
-			indices[j] = indices[j-1] + 1
-		}
+	var subsets [][5]uint8
+	for _, c := range combinations(indices, 5) {
+		subsets = append(subsets, [5]uint8{uint8(c[0]), uint8(c[1]), uint8(c[2]), uint8(c[3]), uint8(c[4])})
 	}
-	return result
+	return subsets
 }
@@ -70,7 +70,7 @@ func main() {
 	numOpponents := 3
 	iterations := 100000 // Number of simulations
 
-	result, err := deuces.EstimateWinProbability(handMC, boardMC, numOpponents, iterations)
+	result, err := deuces.EstimateWinProbability(handMC, boardMC, nil, numOpponents, iterations)
 	if err != nil {
 		panic(err)
 	}
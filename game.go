@@ -0,0 +1,131 @@
+package deuces
+
+import (
+	"fmt"
+)
+
+// Game is a poker variant, which sets how many hole cards each player holds
+// and how they combine with the board. The zero value is Hold'em. The stud
+// games have no board, and are simulated by EstimateStudEquity.
+type Game int
+
+const (
+	Holdem    Game = iota // Best five of two hole cards and the board
+	Omaha                 // Exactly two of four to six hole cards and three of the board
+	OmahaHiLo             // Omaha with the pot split with the best eight or better low
+	ShortDeck             // Hold'em dealt from a short deck of 36 cards, six to ace
+	Stud                  // Seven-card stud, the best five of each player's seven cards
+	Razz                  // Seven-card stud won by the best ace-to-five low
+	StudHiLo              // Seven-card stud with the pot split with the best eight or better low, or Stud8
+)
+
+// String returns the name of the game.
+func (g Game) String() string {
+	switch g {
+	case Holdem:
+		return "Hold'em"
+	case Omaha:
+		return "Omaha"
+	case OmahaHiLo:
+		return "Omaha Hi-Lo"
+	case ShortDeck:
+		return "Short Deck Hold'em"
+	case Stud:
+		return "Seven Card Stud"
+	case Razz:
+		return "Razz"
+	case StudHiLo:
+		return "Stud Hi-Lo"
+	default:
+		return fmt.Sprintf("Game(%d)", int(g))
+	}
+}
+
+// checkHoleCards returns an error unless n is a valid number of hole cards
+// in the game.
+func (g Game) checkHoleCards(n int) error {
+	switch g {
+	case Holdem, ShortDeck:
+		if n != 2 {
+			return fmt.Errorf("hand must contain exactly two cards, got %d", n)
+		}
+	case Omaha, OmahaHiLo:
+		if n < MinOmahaHoleCards || n > MaxOmahaHoleCards {
+			return fmt.Errorf("hand must contain between %d and %d cards in Omaha, got %d", MinOmahaHoleCards, MaxOmahaHoleCards, n)
+		}
+	case Stud, Razz, StudHiLo:
+		return fmt.Errorf("%v has no board, so its equity is estimated with EstimateStudEquity", g)
+	default:
+		return fmt.Errorf("unknown game %v", g)
+	}
+	return nil
+}
+
+// newEvaluator returns an evaluator for the game's hands.
+func (g Game) newEvaluator() *Evaluator {
+	if g == ShortDeck {
+		return newShortDeckEvaluator()
+	}
+	return NewEvaluator()
+}
+
+// deck returns the cards the game is dealt from.
+func (g Game) deck() []Card {
+	if g == ShortDeck {
+		return shortDeck
+	}
+	return fullDeck
+}
+
+// checkDeck returns an error for a card of sets that is not in the game's
+// deck, or unless the deck still holds needed more cards once those of sets
+// are out of it.
+func (g Game) checkDeck(needed int, sets ...[]Card) error {
+	known := 0
+	for _, cards := range sets {
+		for _, c := range cards {
+			if g == ShortDeck && c.GetRankInt() < shortDeckLowestRank {
+				return fmt.Errorf("card %v is not in a short deck", c)
+			}
+		}
+		known += len(cards)
+	}
+	return checkDealableFrom(len(g.deck()), known, needed)
+}
+
+// evaluate ranks the high hand of hand on a complete board by the rules of
+// the game.
+func (g Game) evaluate(e *Evaluator, hand []Card, board []Card) int {
+	if g == Omaha || g == OmahaHiLo {
+		return e.EvaluateOmaha(hand, board)
+	}
+	return e.Evaluate(hand, board)
+}
+
+// splitsPot reports whether the game splits the pot with the best low.
+func (g Game) splitsPot() bool {
+	return g == OmahaHiLo || g == StudHiLo
+}
+
+// isStud reports whether the game is one of the seven-card stud games.
+func (g Game) isStud() bool {
+	return g == Stud || g == Razz || g == StudHiLo
+}
+
+// evaluateLow ranks the low hand of hand on a complete board in a game that
+// splits the pot.
+func (g Game) evaluateLow(e *Evaluator, hand []Card, board []Card) int {
+	if g == StudHiLo {
+		return e.EvaluateEightLow(hand, board)
+	}
+	return e.EvaluateOmahaEightLow(hand, board)
+}
+
+// checkHoldem returns an error unless opts deal Hold'em, for the simulations
+// built on two card hands and ranges.
+func (o SimulationOptions) checkHoldem() error {
+	if o.Game != Holdem {
+		return fmt.Errorf("only %v is supported, got %v", Holdem, o.Game)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package deuces
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Eight or better lows, ranked from 1 for 5-4-3-2-A to MaxEightLow for
+// 8-7-6-5-4. Aces are low, and straights and flushes do not count.
+const (
+	MaxEightLow = 56
+	NoLow       = MaxEightLow + 1 // Rank of a hand without a qualifying low, worse than every low
+)
+
+var (
+	// lowBits maps a card's rank to its bit in a low mask: ace first, then
+	// two to eight. Nine and above have none.
+	lowBits = [13]uint16{1 << 1, 1 << 2, 1 << 3, 1 << 4, 1 << 5, 1 << 6, 1 << 7, 0, 0, 0, 0, 0, 1}
+	// eightLowRanks maps a mask of five low ranks to its rank.
+	eightLowRanks [256]int16
+)
+
+func init() {
+	// Between two masks of five ranks, the one with the highest differing
+	// rank is the worse low, so lows rank in the order of their masks.
+	rank := int16(1)
+	for mask := range eightLowRanks {
+		if bits.OnesCount(uint(mask)) == 5 {
+			eightLowRanks[mask] = rank
+			rank++
+		}
+	}
+}
+
+// EvaluateEightLow returns the rank of the best eight or better low made of
+// any five of hand and board, or NoLow if there is none.
+func (e *Evaluator) EvaluateEightLow(hand []Card, board []Card) int {
+	mask := lowMask(hand) | lowMask(board)
+	for bits.OnesCount16(mask) > 5 {
+		mask &^= 1 << (bits.Len16(mask) - 1) // drop the highest rank
+	}
+	if bits.OnesCount16(mask) < 5 {
+		return NoLow
+	}
+	return int(eightLowRanks[mask])
+}
+
+// EvaluateOmahaEightLow returns the rank of the best eight or better low made
+// of exactly two hole cards and three board cards, or NoLow if there is none.
+// It takes the same cards as EvaluateOmaha and returns -1 when it would.
+func (e *Evaluator) EvaluateOmahaEightLow(hand []Card, board []Card) int {
+	if len(hand) < MinOmahaHoleCards || len(hand) > MaxOmahaHoleCards || len(board) < 3 || len(board) > 5 {
+		return -1 // Should not happen with valid input
+	}
+
+	best := NoLow
+	for _, b := range threeOf[len(board)] {
+		boardMask := lowBits[board[b[0]].GetRankInt()] | lowBits[board[b[1]].GetRankInt()] | lowBits[board[b[2]].GetRankInt()]
+		if bits.OnesCount16(boardMask) != 3 {
+			continue
+		}
+		for _, h := range twoOf[len(hand)] {
+			mask := boardMask | lowBits[hand[h[0]].GetRankInt()] | lowBits[hand[h[1]].GetRankInt()]
+			if bits.OnesCount16(mask) == 5 {
+				best = min(best, int(eightLowRanks[mask]))
+			}
+		}
+	}
+	return best
+}
+
+// HiLoShowdown is the outcome of a pot split between the best high hand and
+// the best eight or better low. Players are numbered in the order their
+// hands were given.
+type HiLoShowdown struct {
+	HighWinners []int     // Players with the best high hand
+	LowWinners  []int     // Players with the best low, empty when no low qualifies
+	Shares      []float64 // Each player's share of the pot
+	Scooper     int       // Player who wins the whole pot, or -1
+}
+
+// OmahaHiLoShowdown settles an Omaha Hi-Lo pot between hands on a complete
+// board. Half the pot goes to the best high hand and half to the best
+// qualifying low, each half split between tied players, so a player tying
+// for one half gets a quarter of the pot. Without a low, the high hand takes
+// the whole pot.
+func (e *Evaluator) OmahaHiLoShowdown(hands [][]Card, board []Card) (*HiLoShowdown, error) {
+	if len(hands) < 2 || len(hands) > MaxOpponents+1 {
+		return nil, fmt.Errorf("number of players must be between 2 and %d, got %d", MaxOpponents+1, len(hands))
+	}
+	for i, hand := range hands {
+		if err := OmahaHiLo.checkHoleCards(len(hand)); err != nil {
+			return nil, fmt.Errorf("hand %d: %w", i+1, err)
+		}
+	}
+	if len(board) != 5 {
+		return nil, fmt.Errorf("board must contain 5 cards, got %d", len(board))
+	}
+	if err := checkCards(append(append([][]Card{}, hands...), board)...); err != nil {
+		return nil, err
+	}
+
+	high := make([]int, len(hands))
+	low := make([]int, len(hands))
+	for p, hand := range hands {
+		high[p] = e.EvaluateOmaha(hand, board)
+		low[p] = e.EvaluateOmahaEightLow(hand, board)
+	}
+	showdown := &HiLoShowdown{
+		HighWinners: bestPlayers(high, MaxHighCard),
+		LowWinners:  bestPlayers(low, MaxEightLow),
+		Shares:      make([]float64, len(hands)),
+		Scooper:     -1,
+	}
+	splitHiLo(high, low, showdown.Shares)
+	for p, share := range showdown.Shares {
+		if share == 1 {
+			showdown.Scooper = p
+		}
+	}
+	return showdown, nil
+}
+
+// splitHiLo sets shares to each player's share of a pot split between the
+// best high rank and the best low rank, if any low qualifies.
+func splitHiLo(high, low []int, shares []float64) {
+	clear(shares)
+	bestLow, lowWinners := NoLow, 0
+	for _, rank := range low {
+		if rank < bestLow {
+			bestLow, lowWinners = rank, 1
+		} else if rank == bestLow && rank != NoLow {
+			lowWinners++
+		}
+	}
+	highHalf := 1.0
+	if lowWinners > 0 {
+		highHalf = 0.5
+		for p, rank := range low {
+			if rank == bestLow {
+				shares[p] += 0.5 / float64(lowWinners)
+			}
+		}
+	}
+
+	bestHigh, highWinners := MaxHighCard+1, 0
+	for _, rank := range high {
+		if rank < bestHigh {
+			bestHigh, highWinners = rank, 1
+		} else if rank == bestHigh {
+			highWinners++
+		}
+	}
+	for p, rank := range high {
+		if rank == bestHigh {
+			shares[p] += highHalf / float64(highWinners)
+		}
+	}
+}
+
+// bestPlayers returns the players with the lowest rank, if it is no worse
+// than worst.
+func bestPlayers(ranks []int, worst int) []int {
+	best := worst + 1
+	var players []int
+	for p, rank := range ranks {
+		if rank > worst {
+			continue
+		}
+		if rank < best {
+			best, players = rank, []int{p}
+		} else if rank == best {
+			players = append(players, p)
+		}
+	}
+	return players
+}
+
+// lowMask returns the mask of the low ranks among cards.
+func lowMask(cards []Card) uint16 {
+	var mask uint16
+	for _, c := range cards {
+		mask |= lowBits[c.GetRankInt()]
+	}
+	return mask
+}
@@ -0,0 +1,226 @@
+package deuces
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+)
+
+// EquityHistogram is the distribution of a hand's equity on the river, over
+// every runout of the board, against one opponent. Bucket i of n holds the
+// share of runouts where the equity is in [i/n, (i+1)/n), the last bucket
+// also holding an equity of 1.
+//
+// Each runout counts in proportion to the weight of the opponent's combos it
+// does not block, which is how likely it is given the opponent's range, so
+// that Mean is the hand's equity against that range.
+type EquityHistogram struct {
+	Buckets         []float64 // Share of runouts whose river equity falls in each bucket
+	Mean            float64   // Mean river equity over the runouts
+	TotalIterations int       // Total number of runouts sampled or enumerated
+	Exact           bool      // Whether every runout was enumerated rather than sampled
+}
+
+// String provides a formatted string representation of the histogram
+func (h EquityHistogram) String() string {
+	source := fmt.Sprintf("from %d iterations", h.TotalIterations)
+	if h.Exact {
+		source = fmt.Sprintf("exact, from %d runouts", h.TotalIterations)
+	}
+	return fmt.Sprintf("Mean equity: %.2f%% over %d buckets (%s)", h.Mean*100, len(h.Buckets), source)
+}
+
+// histogramTally accumulates the weight of runouts by bucket of river equity.
+type histogramTally struct {
+	buckets  []float64
+	equity   float64 // sum of the weighted equities, for the mean
+	equitySq float64 // sum of the weighted squared equities, for its standard error
+	weight   float64
+	total    int
+}
+
+func newHistogramTally(buckets int) histogramTally {
+	return histogramTally{buckets: make([]float64, buckets)}
+}
+
+// record adds a runout of the given weight where the hand has equity.
+func (t *histogramTally) record(equity, weight float64) {
+	t.buckets[min(int(equity*float64(len(t.buckets))), len(t.buckets)-1)] += weight
+	t.equity += equity * weight
+	t.equitySq += equity * equity * weight
+	t.weight += weight
+	t.total++
+}
+
+func (t histogramTally) count() int {
+	return t.total
+}
+
+// reset empties the tally so it can be reused.
+func (t *histogramTally) reset() {
+	clear(t.buckets)
+	t.equity, t.equitySq, t.weight, t.total = 0, 0, 0, 0
+}
+
+func (t *histogramTally) add(other histogramTally) {
+	for i := range t.buckets {
+		t.buckets[i] += other.buckets[i]
+	}
+	t.equity += other.equity
+	t.equitySq += other.equitySq
+	t.weight += other.weight
+	t.total += other.total
+}
+
+// stdErr returns the largest standard error of the mean and of the share of
+// any bucket.
+func (t histogramTally) stdErr() float64 {
+	if t.weight == 0 {
+		return math.Inf(1)
+	}
+	h := t.result(false)
+	stdErr := meanStdErr(h.Mean, t.equitySq/t.weight, t.total)
+	for _, share := range h.Buckets {
+		stdErr = max(stdErr, proportionStdErr(share, t.total))
+	}
+	return stdErr
+}
+
+// result converts the tally into shares, which are all zero if every runout
+// had a weight of zero.
+func (t histogramTally) result(exact bool) *EquityHistogram {
+	h := &EquityHistogram{Buckets: make([]float64, len(t.buckets)), TotalIterations: t.total, Exact: exact}
+	if t.weight == 0 {
+		return h
+	}
+	for i, w := range t.buckets {
+		h.Buckets[i] = w / t.weight
+	}
+	h.Mean = t.equity / t.weight
+	return h
+}
+
+// EnumerateEquityHistogram computes the exact distribution of hand's river
+// equity, in the given number of buckets, over every runout of board against
+// an opponent holding a combo of their range. A nil range stands for any two
+// cards. Dead cards are never dealt. Every runout is ranked against every
+// combo, so before the flop sampling with EstimateEquityHistogram is the
+// practical choice.
+func EnumerateEquityHistogram(hand []Card, board []Card, dead []Card, opponent *Range, buckets int) (*EquityHistogram, error) {
+	combos, err := histogramInput(hand, board, dead, opponent, buckets)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluator := NewEvaluator()
+	stub := &Deck{Cards: GetFullDeck()}
+	stub.Remove(hand...)
+	stub.Remove(board...)
+	stub.Remove(dead...)
+	runouts := completeBoards(board, stub.Cards)
+
+	tallies := make([]histogramTally, runtime.NumCPU())
+	for w := range tallies {
+		tallies[w] = newHistogramTally(buckets)
+	}
+	parallelFor(len(runouts), func(worker, i int) {
+		tallies[worker].record(riverEquity(evaluator, hand, runouts[i][:], combos))
+	})
+
+	total := newHistogramTally(buckets)
+	for _, tally := range tallies {
+		total.add(tally)
+	}
+	return total.result(true), nil
+}
+
+// EstimateEquityHistogram estimates the distribution of
+// EnumerateEquityHistogram by sampling a runout for each iteration, with the
+// seed, workers, random source and target precision of the mean and of every
+// bucket set by opts. The equity on each sampled
+// runout is still computed against every combo of the range. It stops
+// promptly once ctx is done, returning the histogram of the iterations run
+// so far together with ctx.Err().
+func EstimateEquityHistogram(ctx context.Context, hand []Card, board []Card, dead []Card, opponent *Range, buckets int, iterations int, opts SimulationOptions) (*EquityHistogram, error) {
+	combos, err := histogramInput(hand, board, dead, opponent, buckets)
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.validateIterations(iterations); err != nil {
+		return nil, err
+	}
+	if err := opts.checkHoldem(); err != nil {
+		return nil, err
+	}
+
+	evaluator := NewEvaluator()
+
+	samplers := make([]*sampler, opts.workers())
+	for w := range samplers {
+		samplers[w] = newSampler(hand, board, dead)
+	}
+
+	newTally := func() histogramTally { return newHistogramTally(buckets) }
+	total, err := simulateBatches(ctx, iterations, opts, newTally, func(worker int, rng *rand.Rand, workerIter int, batch *histogramTally) {
+		deck := samplers[worker]
+		deck.reset()
+
+		var runout [5]Card
+		copy(runout[:], board)
+		for j := 0; j < workerIter; j++ {
+			deck.newDeal()
+			copy(runout[len(board):], deck.draw(rng, 5-len(board)))
+			batch.record(riverEquity(evaluator, hand, runout[:], combos))
+		}
+	}, nil)
+	return total.result(false), err
+}
+
+// riverEquity returns the equity of hand on a complete board against the
+// combos that share no card with it, along with their total weight.
+func riverEquity(e *Evaluator, hand []Card, board []Card, combos []rangeCombo) (equity, weight float64) {
+	boardMask := cardMask(board)
+	heroRank := e.Evaluate(hand, board)
+	won := 0.0
+	for i := range combos {
+		c := &combos[i]
+		if c.mask&boardMask != 0 {
+			continue
+		}
+		rank := e.Evaluate(c.cards[:], board)
+		if rank > heroRank {
+			won += c.weight
+		} else if rank == heroRank {
+			won += c.weight / 2
+		}
+		weight += c.weight
+	}
+	if weight == 0 {
+		return 0, 0
+	}
+	return won / weight, weight
+}
+
+// histogramInput checks the arguments shared by the functions that compute
+// equity histograms and returns the combos the opponent can hold.
+func histogramInput(hand []Card, board []Card, dead []Card, opponent *Range, buckets int) ([]rangeCombo, error) {
+	if buckets < 1 {
+		return nil, fmt.Errorf("number of buckets must be at least 1, got %d", buckets)
+	}
+	if err := validateWinProbabilityInput(hand, board, dead, 1); err != nil {
+		return nil, err
+	}
+	if opponent == nil {
+		opponent = NewRange()
+		for i := range opponent.weights {
+			opponent.weights[i] = 1
+		}
+	}
+	combos := liveCombos(opponent, cardMask(hand)|cardMask(board)|cardMask(dead))
+	if len(combos) == 0 {
+		return nil, fmt.Errorf("opponent range has no combos left once the hand, board and dead cards are removed")
+	}
+	return combos, nil
+}
@@ -5,11 +5,23 @@ import (
 )
 
 // LookupTable stores the precomputed lookup tables for poker hand evaluation.
+//
+// FlushLookup and UnsuitedLookup map prime products to hand ranks and are kept
+// for inspection. Evaluation itself goes through flat arrays: flushes and
+// hands with five distinct ranks are indexed directly by their rank bits, and
+// paired hands by a perfect hash of their prime product.
 type LookupTable struct {
 	FlushLookup    map[int]int
 	UnsuitedLookup map[int]int
+
+	flushRanks  [rankbitsSize]uint16
+	uniqueRanks [rankbitsSize]uint16
+	pairedHash  perfectHash
 }
 
+// rankbitsSize is the number of distinct 13-bit rank masks.
+const rankbitsSize = 1 << 13
+
 const (
 	MaxStraightFlush = 10
 	MaxFourOfAKind   = 166
@@ -59,6 +71,27 @@ func NewLookupTable() *LookupTable {
 	return lt
 }
 
+// lookup returns the rank of the five given cards.
+func (lt *LookupTable) lookup(c0, c1, c2, c3, c4 Card) int {
+	rankbits := int(c0|c1|c2|c3|c4) >> 16
+
+	// if flush; repeated cards fall through to the unsuited lookup, as with the maps
+	if c0&c1&c2&c3&c4&0xF000 != 0 {
+		if rank := lt.flushRanks[rankbits]; rank != 0 {
+			return int(rank)
+		}
+	}
+
+	// five distinct ranks: straight or high card
+	if rank := lt.uniqueRanks[rankbits]; rank != 0 {
+		return int(rank)
+	}
+
+	// otherwise at least one pair
+	product := uint32(c0&0x3F) * uint32(c1&0x3F) * uint32(c2&0x3F) * uint32(c3&0x3F) * uint32(c4&0x3F)
+	return lt.pairedHash.get(product)
+}
+
 func (lt *LookupTable) flushes() {
 	straightFlushes := []int{
 		7936, // 0b1111100000000, // royal flush
@@ -96,6 +129,7 @@ func (lt *LookupTable) flushes() {
 	for _, sf := range straightFlushes {
 		primeProduct := primeProductFromRankbits(sf)
 		lt.FlushLookup[primeProduct] = rank
+		lt.flushRanks[sf] = uint16(rank)
 		rank++
 	}
 
@@ -103,6 +137,7 @@ func (lt *LookupTable) flushes() {
 	for _, f := range flushes {
 		primeProduct := primeProductFromRankbits(f)
 		lt.FlushLookup[primeProduct] = rank
+		lt.flushRanks[f] = uint16(rank)
 		rank++
 	}
 
@@ -114,6 +149,7 @@ func (lt *LookupTable) straightAndHighcards(straights, highcards []int) {
 	for _, s := range straights {
 		primeProduct := primeProductFromRankbits(s)
 		lt.UnsuitedLookup[primeProduct] = rank
+		lt.uniqueRanks[s] = uint16(rank)
 		rank++
 	}
 
@@ -121,11 +157,15 @@ func (lt *LookupTable) straightAndHighcards(straights, highcards []int) {
 	for _, h := range highcards {
 		primeProduct := primeProductFromRankbits(h)
 		lt.UnsuitedLookup[primeProduct] = rank
+		lt.uniqueRanks[h] = uint16(rank)
 		rank++
 	}
 }
 
 func (lt *LookupTable) multiples() {
+	// paired collects every hand with at least one pair for the perfect hash
+	paired := make(map[uint32]uint16)
+
 	backwardsRanks := make([]int, len(IntRanks))
 	for i := 0; i < len(IntRanks); i++ {
 		backwardsRanks[i] = len(IntRanks) - 1 - i
@@ -143,6 +183,7 @@ func (lt *LookupTable) multiples() {
 		for _, k := range kickers {
 			product := pow(Primes[i], 4) * Primes[k]
 			lt.UnsuitedLookup[product] = rank
+			paired[uint32(product)] = uint16(rank)
 			rank++
 		}
 	}
@@ -159,6 +200,7 @@ func (lt *LookupTable) multiples() {
 		for _, pr := range pairRanks {
 			product := pow(Primes[i], 3) * pow(Primes[pr], 2)
 			lt.UnsuitedLookup[product] = rank
+			paired[uint32(product)] = uint16(rank)
 			rank++
 		}
 	}
@@ -177,6 +219,7 @@ func (lt *LookupTable) multiples() {
 			c1, c2 := c[0], c[1]
 			product := pow(Primes[r], 3) * Primes[c1] * Primes[c2]
 			lt.UnsuitedLookup[product] = rank
+			paired[uint32(product)] = uint16(rank)
 			rank++
 		}
 	}
@@ -195,6 +238,7 @@ func (lt *LookupTable) multiples() {
 		for _, kicker := range kickers {
 			product := pow(Primes[pair1], 2) * pow(Primes[pair2], 2) * Primes[kicker]
 			lt.UnsuitedLookup[product] = rank
+			paired[uint32(product)] = uint16(rank)
 			rank++
 		}
 	}
@@ -213,9 +257,12 @@ func (lt *LookupTable) multiples() {
 			k1, k2, k3 := k[0], k[1], k[2]
 			product := pow(Primes[pairRank], 2) * Primes[k1] * Primes[k2] * Primes[k3]
 			lt.UnsuitedLookup[product] = rank
+			paired[uint32(product)] = uint16(rank)
 			rank++
 		}
 	}
+
+	lt.pairedHash.build(paired)
 }
 
 // Helper functions
@@ -0,0 +1,190 @@
+package deuces
+
+// Number of distinct five card hands in each lowball ranking.
+const (
+	MaxAceToFive    = 6175
+	MaxDeuceToSeven = 7462
+)
+
+var (
+	// AceToFiveClassToString names the classes of ace-to-five lowball, where
+	// straights and flushes do not count.
+	AceToFiveClassToString = map[int]string{
+		1: "High Card",
+		2: "Pair",
+		3: "Two Pair",
+		4: "Three of a Kind",
+		5: "Full House",
+		6: "Four of a Kind",
+	}
+
+	// DeuceToSevenClassToString names the classes of deuce-to-seven lowball,
+	// best first.
+	DeuceToSevenClassToString = map[int]string{
+		1: "High Card",
+		2: "Pair",
+		3: "Two Pair",
+		4: "Three of a Kind",
+		5: "Straight",
+		6: "Flush",
+		7: "Full House",
+		8: "Four of a Kind",
+		9: "Straight Flush",
+	}
+)
+
+// LowballEvaluator evaluates hands for lowball games, where the lowest hand
+// wins. Like Evaluator, it returns ranks where lower is better, from 1 for
+// the best hand, but on the lowball game's own scale.
+type LowballEvaluator struct {
+	lookupTable *LookupTable
+	classMax    []int // the worst rank of each class, best class first
+	classNames  map[int]string
+}
+
+// NewAceToFiveEvaluator creates an evaluator for ace-to-five lowball, as in
+// Razz and California lowball. Aces are low and straights and flushes do not
+// count, so 5-4-3-2-A is the best hand.
+func NewAceToFiveEvaluator() *LowballEvaluator {
+	return newLowballEvaluator(aceToFiveKey, AceToFiveClassToString)
+}
+
+// NewDeuceToSevenEvaluator creates an evaluator for deuce-to-seven lowball.
+// Aces are always high and straights and flushes count against the hand, so
+// 7-5-4-3-2 of at least two suits is the best hand and A-5-4-3-2 is ace high.
+func NewDeuceToSevenEvaluator() *LowballEvaluator {
+	return newLowballEvaluator(deuceToSevenKey, DeuceToSevenClassToString)
+}
+
+// Evaluate returns the rank of the best lowball hand made of five of the
+// hand and board cards, which hold five to seven cards together. It returns
+// -1 for any other number of cards.
+func (e *LowballEvaluator) Evaluate(hand []Card, board []Card) int {
+	n := len(hand) + len(board)
+	if n < 5 || n > 7 {
+		return -1 // Should not happen with valid input
+	}
+
+	var cards [7]Card
+	copy(cards[:], hand)
+	copy(cards[len(hand):], board)
+
+	switch n {
+	case 5:
+		return e.lookupTable.lookup(cards[0], cards[1], cards[2], cards[3], cards[4])
+	case 6:
+		return e.evaluateBestFive(&cards, fiveOfSix)
+	default:
+		return e.evaluateBestFive(&cards, fiveOfSeven)
+	}
+}
+
+// EvaluateChecked evaluates a hand like Evaluate, but validates the cards
+// first, returning the same errors as Evaluator.EvaluateChecked.
+func (e *LowballEvaluator) EvaluateChecked(hand []Card, board []Card) (int, error) {
+	if n := len(hand) + len(board); n < 5 || n > 7 {
+		return 0, &CardCountError{Count: n}
+	}
+	if err := checkCards(hand, board); err != nil {
+		return 0, err
+	}
+	return e.Evaluate(hand, board), nil
+}
+
+// GetRankClass returns the class of a lowball hand given its rank, numbered
+// from 1 for the best class, or -1 for an invalid rank.
+func (e *LowballEvaluator) GetRankClass(handRank int) int {
+	if handRank < 1 {
+		return -1
+	}
+	for i, max := range e.classMax {
+		if handRank <= max {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// ClassToString converts a class returned by GetRankClass into its name.
+func (e *LowballEvaluator) ClassToString(classInt int) string {
+	return e.classNames[classInt]
+}
+
+// evaluateBestFive returns the best rank among the given five card subsets.
+func (e *LowballEvaluator) evaluateBestFive(cards *[7]Card, subsets [][5]uint8) int {
+	minimum := MaxDeuceToSeven
+	for _, s := range subsets {
+		score := e.lookupTable.lookup(cards[s[0]], cards[s[1]], cards[s[2]], cards[s[3]], cards[s[4]])
+		if score < minimum {
+			minimum = score
+		}
+	}
+	return minimum
+}
+
+// newLowballEvaluator ranks every five card hand by key and builds the
+// tables that look the ranks up.
+func newLowballEvaluator(key handKey, classNames map[int]string) *LowballEvaluator {
+	ranked := rankFiveCardHands(key, 0)
+	e := &LowballEvaluator{
+		lookupTable: newRankedLookupTable(ranked),
+		classMax:    make([]int, len(classNames)),
+		classNames:  classNames,
+	}
+	for _, h := range ranked {
+		e.classMax[h.class-1] = h.rank
+	}
+	return e
+}
+
+// aceToFiveKey orders hands with aces low, ignoring straights and flushes:
+// by pairing, then by the values of the largest groups, highest first.
+func aceToFiveKey(h fiveCardHand) (int, int) {
+	sizes := groupSizes(h.counts)
+	var class int
+	switch {
+	case sizes[0] == 4:
+		class = 6
+	case sizes[0] == 3 && sizes[1] == 2:
+		class = 5
+	case sizes[0] == 3:
+		class = 4
+	case sizes[0] == 2 && sizes[1] == 2:
+		class = 3
+	case sizes[0] == 2:
+		class = 2
+	default:
+		class = 1
+	}
+	return groupKey(class, h.counts, func(rank int) int { return (rank + 1) % 13 }), class
+}
+
+// deuceToSevenKey orders hands as the reverse of high hands, with aces always
+// high, so that A-5-4-3-2 is not a straight.
+func deuceToSevenKey(h fiveCardHand) (int, int) {
+	sizes := groupSizes(h.counts)
+	straight := straightTop(h.counts, -1) >= 0
+
+	var class int
+	switch {
+	case straight && h.suited:
+		class = 9
+	case sizes[0] == 4:
+		class = 8
+	case sizes[0] == 3 && sizes[1] == 2:
+		class = 7
+	case h.suited:
+		class = 6
+	case straight:
+		class = 5
+	case sizes[0] == 3:
+		class = 4
+	case sizes[0] == 2 && sizes[1] == 2:
+		class = 3
+	case sizes[0] == 2:
+		class = 2
+	default:
+		class = 1
+	}
+	return groupKey(class, h.counts, func(rank int) int { return rank }), class
+}
@@ -1,175 +1,398 @@
 package deuces
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const (
 	MaxOpponents  = 9
 	MinIterations = 1000
+
+	// ProgressInterval is the least time between two progress snapshots
+	// from EstimateWinProbabilityContext.
+	ProgressInterval = 100 * time.Millisecond
 )
 
+// ProgressFunc receives a snapshot of a simulation's results so far.
+type ProgressFunc func(partial HandResult)
+
+// SimulationOptions configures a Monte Carlo simulation. The zero value runs
+// one worker per CPU, seeded from the clock.
+//
+// Iterations are split into fixed blocks, each drawing from its own stream
+// derived from Seed, so a given seed always gives the same deals, however
+// many workers run and however they are scheduled. Blocks are added to the
+// results in the order they finish, so results built on fractional shares
+// of the pot may still differ in their last digits.
+//
+// When Precision is set, the iterations passed to a simulation are a budget:
+// it stops as soon as every estimate is within ±Precision of its true value
+// at the Confidence level, so the number of iterations run, unlike the
+// streams they draw from, depends on scheduling.
+type SimulationOptions struct {
+	Seed       int64                        // Master seed for every stream; 0 seeds from the clock
+	Workers    int                          // Number of goroutines; 0 uses runtime.NumCPU()
+	NewSource  func(seed int64) rand.Source // Random source for each worker; nil uses rand.NewSource
+	Progress   ProgressFunc                 // Receives snapshots from EstimateWinProbabilityWithOptions, if not nil
+	Precision  float64                      // Target half-width of the confidence intervals, such as 0.0025; 0 runs every iteration
+	Confidence float64                      // Confidence level for Precision; 0 uses DefaultConfidence
+	Game       Game                         // Game whose hands are dealt and ranked, in the simulations that support it; 0 is Holdem
+}
+
+// workers returns the number of goroutines to run.
+func (o SimulationOptions) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return runtime.NumCPU()
+}
+
 // HandResult represents the complete breakdown of Monte Carlo simulation results
 type HandResult struct {
 	WinProbability      float64 // Probability of having the best hand
 	TieProbability      float64 // Probability of tying for the best hand
 	LossProbability     float64 // Probability of losing
 	WinOrTieProbability float64 // Combined probability of winning or tying (not losing money)
-	TotalIterations     int     // Total number of simulations run
+	TotalIterations     int     // Total number of simulations run, or of showdowns enumerated
+	Exact               bool    // Whether every showdown was enumerated rather than sampled
+	WinStdErr           float64 // Standard error of WinProbability, 0 when exact
+	TieStdErr           float64 // Standard error of TieProbability, 0 when exact
+
+	// Equity is the expected share of the pot, counting a k-way split as
+	// 1/k, and TieSizes counts the ties by k, the number of players
+	// splitting the pot including the hero.
+	Equity       float64
+	EquityStdErr float64 // Standard error of Equity, 0 when exact
+	TieSizes     [MaxOpponents + 2]int
 }
 
 // String provides a formatted string representation of the results
 func (hr HandResult) String() string {
+	source := fmt.Sprintf("from %d iterations", hr.TotalIterations)
+	if hr.Exact {
+		source = fmt.Sprintf("exact, from %d showdowns", hr.TotalIterations)
+	}
 	return fmt.Sprintf(
-		"Win: %.2f%%, Tie: %.2f%%, Loss: %.2f%%, Win+Tie: %.2f%% (from %d iterations)",
+		"Win: %.2f%%, Tie: %.2f%%, Loss: %.2f%%, Win+Tie: %.2f%%, Equity: %.2f%% (%s)",
 		hr.WinProbability*100,
 		hr.TieProbability*100,
 		hr.LossProbability*100,
 		hr.WinOrTieProbability*100,
-		hr.TotalIterations,
+		hr.Equity*100,
+		source,
 	)
 }
 
+// showdownTally counts showdown outcomes from the hero's point of view.
+type showdownTally struct {
+	wins     int
+	ties     int
+	losses   int
+	tieSizes [MaxOpponents + 2]int
+}
+
+// record adds the outcome of a showdown the hero did not lose to any of
+// tiedOpponents, or lost if lost is set.
+func (t *showdownTally) record(lost bool, tiedOpponents int) {
+	switch {
+	case lost:
+		t.losses++
+	case tiedOpponents > 0:
+		t.ties++
+		t.tieSizes[tiedOpponents+1]++
+	default:
+		t.wins++
+	}
+}
+
+func (t *showdownTally) add(other showdownTally) {
+	t.wins += other.wins
+	t.ties += other.ties
+	t.losses += other.losses
+	for k := range t.tieSizes {
+		t.tieSizes[k] += other.tieSizes[k]
+	}
+}
+
+func (t showdownTally) count() int {
+	return t.wins + t.ties + t.losses
+}
+
+// reset empties the tally so it can be reused.
+func (t *showdownTally) reset() {
+	*t = showdownTally{}
+}
+
+// stdErr returns the largest standard error of the probabilities.
+func (t showdownTally) stdErr() float64 {
+	result := t.result(false)
+	return max(result.WinStdErr, result.TieStdErr, result.EquityStdErr)
+}
+
+// result converts the tally into probabilities, which are all zero if no
+// showdown was recorded.
+func (t showdownTally) result(exact bool) *HandResult {
+	if t.count() == 0 {
+		return &HandResult{Exact: exact}
+	}
+	// A k-way split is worth 1/k of the pot
+	shares, sharesSq := float64(t.wins), float64(t.wins)
+	for k := 2; k < len(t.tieSizes); k++ {
+		shares += float64(t.tieSizes[k]) / float64(k)
+		sharesSq += float64(t.tieSizes[k]) / float64(k*k)
+	}
+
+	total := float64(t.count())
+	result := &HandResult{
+		WinProbability:      float64(t.wins) / total,
+		TieProbability:      float64(t.ties) / total,
+		LossProbability:     float64(t.losses) / total,
+		WinOrTieProbability: float64(t.wins+t.ties) / total,
+		TotalIterations:     t.count(),
+		Exact:               exact,
+		Equity:              shares / total,
+		TieSizes:            t.tieSizes,
+	}
+	if !exact {
+		result.WinStdErr = proportionStdErr(result.WinProbability, t.count())
+		result.TieStdErr = proportionStdErr(result.TieProbability, t.count())
+		result.EquityStdErr = meanStdErr(result.Equity, sharesSq/total, t.count())
+	}
+	return result
+}
+
 // EstimateWinProbability estimates the probability of winning a poker hand using Monte Carlo simulation.
 // It returns a detailed breakdown of win/tie/loss probabilities.
-func EstimateWinProbability(hand []Card, board []Card, numOpponents int, iterations int) (*HandResult, error) {
+// Dead cards, such as mucked or exposed cards, are removed from the deck
+// along with the hand and board, and may be nil.
+// Malformed or repeated cards in hand, board and dead are reported with the
+// same errors as Evaluator.EvaluateChecked.
+func EstimateWinProbability(hand []Card, board []Card, dead []Card, numOpponents int, iterations int) (*HandResult, error) {
+	return EstimateWinProbabilityContext(context.Background(), hand, board, dead, numOpponents, iterations, nil)
+}
+
+// EstimateWinProbabilityContext is like EstimateWinProbability, but stops
+// promptly once ctx is done, returning the results of the iterations run so
+// far together with ctx.Err(). If progress is not nil, it is called with a
+// snapshot of the results at most every ProgressInterval. It is called from
+// the simulation's goroutines, one call at a time, and should return quickly.
+func EstimateWinProbabilityContext(ctx context.Context, hand []Card, board []Card, dead []Card, numOpponents int, iterations int, progress ProgressFunc) (*HandResult, error) {
+	return EstimateWinProbabilityWithOptions(ctx, hand, board, dead, numOpponents, iterations, SimulationOptions{Progress: progress})
+}
+
+// EstimateWinProbabilityWithOptions is like EstimateWinProbabilityContext,
+// with the seed, workers, random source, progress callback, target precision
+// and game set by opts. In Omaha, each opponent is dealt as many hole cards
+// as the hand holds.
+func EstimateWinProbabilityWithOptions(ctx context.Context, hand []Card, board []Card, dead []Card, numOpponents int, iterations int, opts SimulationOptions) (*HandResult, error) {
 	// Input Validation
-	if len(hand) != 2 {
-		return nil, fmt.Errorf("hand must contain exactly two cards, got %d", len(hand))
-	}
-	if len(board) > 5 {
-		return nil, fmt.Errorf("board must contain between 0 and 5 cards, got %d", len(board))
-	}
-	if numOpponents < 0 {
-		return nil, fmt.Errorf("number of opponents cannot be negative, got %d", numOpponents)
+	if err := validateGameInput(opts.Game, hand, board, dead, numOpponents); err != nil {
+		return nil, err
 	}
-	if numOpponents > MaxOpponents {
-		return nil, fmt.Errorf("number of opponents should not exceed %d for a full player game, got %d", MaxOpponents, numOpponents)
+	if err := opts.validateIterations(iterations); err != nil {
+		return nil, err
 	}
-	if iterations < MinIterations {
-		return nil, fmt.Errorf("iterations should be at least %d to ensure reliability, got %d", MinIterations, iterations)
+	if opts.Game.splitsPot() {
+		return nil, fmt.Errorf("%v splits pots, so its equity is estimated with EstimateEquityWithOptions", opts.Game)
 	}
 
 	// Initialize evaluator
-	evaluator := NewEvaluator()
+	evaluator := opts.Game.newEvaluator()
 
-	// Use a WaitGroup to wait for all goroutines to finish
-	var wg sync.WaitGroup
-
-	// Channel to collect results from goroutines
-	type workerResult struct {
-		wins   int
-		ties   int
-		losses int
+	// Each worker deals from its own stub of the cards that are not known
+	samplers := make([]*sampler, opts.workers())
+	for w := range samplers {
+		samplers[w] = newDeckSampler(opts.Game.deck(), hand, board, dead)
 	}
-	results := make(chan workerResult, runtime.NumCPU())
 
-	// Determine the number of goroutines to use
-	numWorkers := min(runtime.NumCPU(), iterations)
-	iterationsPerWorker := iterations / numWorkers
-	remainingIterations := iterations % numWorkers
+	return simulateShowdowns(ctx, iterations, opts, func(worker int, rng *rand.Rand, workerIter int, tally *showdownTally) {
+		deck := samplers[worker]
+		deck.reset()
+
+		var currentBoard [5]Card
+		copy(currentBoard[:], board)
 
-	// Launch goroutines
-	for i := 0; i < numWorkers; i++ {
-		workerIterations := iterationsPerWorker
-		if i < remainingIterations {
-			workerIterations++ // Distribute remaining iterations
+		for j := 0; j < workerIter; j++ {
+			// Deal remaining board cards
+			deck.newDeal()
+			copy(currentBoard[len(board):], deck.draw(rng, 5-len(board)))
+
+			// Evaluate user's hand
+			userRank := opts.Game.evaluate(evaluator, hand, currentBoard[:])
+
+			// Simulate opponents' hands and track results
+			lost := false
+			tiedOpponents := 0
+
+			for k := 0; k < numOpponents; k++ {
+				opponentHand := deck.draw(rng, len(hand))
+				opponentRank := opts.Game.evaluate(evaluator, opponentHand, currentBoard[:])
+
+				if opponentRank < userRank { // Opponent has a better hand (lower rank = better)
+					lost = true
+					break // User loses, no need to check other opponents
+				} else if opponentRank == userRank { // Tie with this opponent
+					tiedOpponents++
+				}
+			}
+
+			// Categorize the result
+			tally.record(lost, tiedOpponents)
 		}
+	})
+}
 
-		wg.Add(1)
-		go func(workerID int, workerIter int) {
-			defer wg.Done()
+// simulateShowdowns runs a simulation from the hero's point of view through
+// simulateBatches, reporting progress as set by opts.
+func simulateShowdowns(ctx context.Context, iterations int, opts SimulationOptions, simulate func(worker int, rng *rand.Rand, iterations int, tally *showdownTally)) (*HandResult, error) {
+	lastProgress := time.Now()
+	progress := func(total *showdownTally) {
+		if opts.Progress != nil && time.Since(lastProgress) >= ProgressInterval {
+			lastProgress = time.Now()
+			opts.Progress(*total.result(false))
+		}
+	}
 
-			var workerWins, workerTies, workerLosses int
+	// The probabilities are partial if ctx stopped the workers
+	total, err := simulateBatches(ctx, iterations, opts, func() showdownTally { return showdownTally{} }, simulate, progress)
+	return total.result(false), err
+}
 
-			// Create a unique random source for each goroutine
-			// Use workerID and current time to ensure different sequences
-			seed := time.Now().UnixNano() + int64(workerID)*1000000
-			rng := rand.New(rand.NewSource(seed))
+// tally is the results of a simulation, which each block of iterations is
+// recorded in before being added to the total.
+type tally[T any] interface {
+	*T
+	add(other T)
+	reset()
+	stdErr() float64 // the largest standard error of the estimates
+	count() int      // the number of samples
+}
 
-			for j := 0; j < workerIter; j++ {
-				// Create a fresh deck for each iteration
-				deck := NewDeckWithRNG(rng)
+// simulateBatches runs a simulation through runWorkers. simulate records one
+// block of iterations in a batch, which is then added to the total, stopping
+// once the total meets the precision set by opts. If merged is not nil, it is
+// called with the total after each batch, one call at a time. The total is
+// partial, along with ctx.Err(), if ctx stopped the workers.
+func simulateBatches[T any, P tally[T]](ctx context.Context, iterations int, opts SimulationOptions, newTally func() T, simulate func(worker int, rng *rand.Rand, iterations int, batch P), merged func(total P)) (P, error) {
+	var mu sync.Mutex
+	t := newTally()
+	total := P(&t)
+	batches := make([]T, opts.workers())
+	for w := range batches {
+		batches[w] = newTally()
+	}
 
-				// Combine known cards for removal
-				allKnownCards := append(hand, board...)
-				deck.Remove(allKnownCards...)
+	runWorkers(ctx, iterations, opts, func(worker int, rng *rand.Rand, workerIter int) bool {
+		batch := P(&batches[worker])
+		batch.reset()
+		simulate(worker, rng, workerIter, batch)
 
-				// Deal remaining board cards
-				currentBoard := make([]Card, len(board))
-				copy(currentBoard, board) // Copy to avoid modifying the original board slice
+		mu.Lock()
+		defer mu.Unlock()
+		total.add(*batch)
+		if merged != nil {
+			merged(total)
+		}
+		return opts.precise(total.stdErr(), total.count())
+	})
 
-				for len(currentBoard) < 5 {
-					currentBoard = append(currentBoard, deck.Draw(1)...)
-				}
+	if err := ctx.Err(); err != nil && total.count() < iterations {
+		return total, err
+	}
+	return total, nil
+}
 
-				// Evaluate user's hand
-				userRank := evaluator.Evaluate(hand, currentBoard)
+// workerBatch is the number of iterations in a block: the most a worker runs
+// between two checks of its context, and the length of each random stream.
+const workerBatch = 1000
 
-				// Simulate opponents' hands and track results
-				userHasBestHand := true
-				userTiedForBest := false
+// runWorkers runs iterations in blocks of workerBatch, shared out between
+// opts.workers() goroutines, and stops once ctx is done or fn returns true.
+// fn is called for each block with its length and a random source seeded
+// for that block alone. Workers are numbered from 0 to opts.workers()-1 so
+// fn can keep per-worker results.
+func runWorkers(ctx context.Context, iterations int, opts SimulationOptions, fn func(worker int, rng *rand.Rand, iterations int) (stop bool)) {
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	newSource := opts.NewSource
+	if newSource == nil {
+		newSource = rand.NewSource
+	}
 
-				for k := 0; k < numOpponents; k++ {
-					opponentHand := deck.Draw(2)
-					opponentRank := evaluator.Evaluate(opponentHand, currentBoard)
+	blocks := (iterations + workerBatch - 1) / workerBatch
+	var next atomic.Int64
+	var stopped atomic.Bool
+	var wg sync.WaitGroup
 
-					if opponentRank < userRank { // Opponent has a better hand (lower rank = better)
-						userHasBestHand = false
-						userTiedForBest = false
-						break // User loses, no need to check other opponents
-					} else if opponentRank == userRank { // Tie with this opponent
-						userTiedForBest = true
-					}
-				}
+	for w := 0; w < min(opts.workers(), blocks); w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
 
-				// Categorize the result
-				if userHasBestHand && !userTiedForBest {
-					workerWins++
-				} else if userHasBestHand && userTiedForBest {
-					workerTies++
-				} else {
-					workerLosses++
+			rng := rand.New(newSource(seed))
+			for ctx.Err() == nil && !stopped.Load() {
+				block := int(next.Add(1)) - 1
+				if block >= blocks {
+					return
+				}
+				rng.Seed(splitSeed(seed, block))
+				if fn(worker, rng, min(workerBatch, iterations-block*workerBatch)) {
+					stopped.Store(true)
 				}
 			}
-
-			results <- workerResult{
-				wins:   workerWins,
-				ties:   workerTies,
-				losses: workerLosses,
-			}
-		}(i, workerIterations)
+		}(w)
 	}
 
 	// Wait for all goroutines to finish
 	wg.Wait()
-	close(results)
+}
 
-	// Aggregate results
-	var totalWins, totalTies, totalLosses int
-	for result := range results {
-		totalWins += result.wins
-		totalTies += result.ties
-		totalLosses += result.losses
-	}
+// splitSeed derives the seed of one block's stream from the master seed,
+// with the SplitMix64 finaliser so that nearby blocks get unrelated seeds.
+func splitSeed(seed int64, block int) int64 {
+	z := uint64(seed) + uint64(block+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64(z ^ (z >> 31))
+}
 
-	// Calculate probabilities
-	fIterations := float64(iterations)
-	return &HandResult{
-		WinProbability:      float64(totalWins) / fIterations,
-		TieProbability:      float64(totalTies) / fIterations,
-		LossProbability:     float64(totalLosses) / fIterations,
-		WinOrTieProbability: float64(totalWins+totalTies) / fIterations,
-		TotalIterations:     iterations,
-	}, nil
+// validateWinProbabilityInput checks the arguments shared by the functions
+// that compute a hand's chances against random opponents.
+func validateWinProbabilityInput(hand []Card, board []Card, dead []Card, numOpponents int) error {
+	return validateGameInput(Holdem, hand, board, dead, numOpponents)
+}
+
+// validateGameInput is like validateWinProbabilityInput for a hand of the
+// given game, with every opponent holding as many cards as the hand.
+func validateGameInput(game Game, hand []Card, board []Card, dead []Card, numOpponents int) error {
+	if err := game.checkHoleCards(len(hand)); err != nil {
+		return err
+	}
+	if len(board) > 5 {
+		return fmt.Errorf("board must contain between 0 and 5 cards, got %d", len(board))
+	}
+	if err := checkCards(hand, board, dead); err != nil {
+		return err
+	}
+	if numOpponents < 0 {
+		return fmt.Errorf("number of opponents cannot be negative, got %d", numOpponents)
+	}
+	if numOpponents > MaxOpponents {
+		return fmt.Errorf("number of opponents should not exceed %d for a full player game, got %d", MaxOpponents, numOpponents)
+	}
+	return game.checkDeck(5-len(board)+len(hand)*numOpponents, hand, board, dead)
 }
 
 // // Basic usage
-// result, err := EstimateWinProbability(hand, board, 3, 100000)
+// result, err := EstimateWinProbability(hand, board, nil, 3, 100000)
 // if err != nil {
 //     log.Fatal(err)
 // }
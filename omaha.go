@@ -0,0 +1,57 @@
+package deuces
+
+// Omaha hands are made of exactly two hole cards and three board cards.
+const (
+	MinOmahaHoleCards = 4
+	MaxOmahaHoleCards = 6
+)
+
+// twoOf and threeOf hold, for each number of cards, the indices of every
+// pair and every triple of them.
+var (
+	twoOf   [MaxOmahaHoleCards + 1][][2]uint8
+	threeOf [6][][3]uint8
+)
+
+func init() {
+	for n := 2; n <= MaxOmahaHoleCards; n++ {
+		for _, c := range combinations(indexRange(n), 2) {
+			twoOf[n] = append(twoOf[n], [2]uint8{uint8(c[0]), uint8(c[1])})
+		}
+	}
+	for n := 3; n <= 5; n++ {
+		for _, c := range combinations(indexRange(n), 3) {
+			threeOf[n] = append(threeOf[n], [3]uint8{uint8(c[0]), uint8(c[1]), uint8(c[2])})
+		}
+	}
+}
+
+// EvaluateOmaha evaluates an Omaha hand of four to six hole cards on a board
+// of three to five cards, using exactly two hole cards and three board cards.
+// Ranks are on the same scale as Evaluate. It returns -1 for any other
+// number of cards, and like Evaluate does not allocate.
+func (e *Evaluator) EvaluateOmaha(hand []Card, board []Card) int {
+	if len(hand) < MinOmahaHoleCards || len(hand) > MaxOmahaHoleCards || len(board) < 3 || len(board) > 5 {
+		return -1 // Should not happen with valid input
+	}
+
+	minimum := MaxHighCard
+	for _, h := range twoOf[len(hand)] {
+		for _, b := range threeOf[len(board)] {
+			score := e.lookupTable.lookup(hand[h[0]], hand[h[1]], board[b[0]], board[b[1]], board[b[2]])
+			if score < minimum {
+				minimum = score
+			}
+		}
+	}
+	return minimum
+}
+
+// indexRange returns the integers from 0 to n-1.
+func indexRange(n int) []int {
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
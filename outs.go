@@ -0,0 +1,175 @@
+package deuces
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Out is an unseen card that puts the hand ahead of an opponent it was not
+// beating before the card.
+type Out struct {
+	Card             Card
+	Class            HandClass // What the hand makes with the card, such as a Flush
+	Share            float64   // Weighted share of the opponent's combos not beaten before that the card beats; 1 against a known hand
+	ImprovesOpponent bool      // Whether the card also improves the class of an opponent combo it beats
+}
+
+// OutsResult lists the outs of a hand on the flop or turn, in deck order.
+type OutsResult struct {
+	Outs   []Out
+	Unseen int // Number of cards the next card can be
+}
+
+// Count returns the number of outs, whatever their share.
+func (r *OutsResult) Count() int {
+	return len(r.Outs)
+}
+
+// Effective returns the number of outs, each counted at its share, which is
+// the count against a known hand.
+func (r *OutsResult) Effective() float64 {
+	total := 0.0
+	for _, out := range r.Outs {
+		total += out.Share
+	}
+	return total
+}
+
+// Probability returns the chance that the next card is an out, counting each
+// out at its share.
+func (r *OutsResult) Probability() float64 {
+	if r.Unseen == 0 {
+		return 0
+	}
+	return r.Effective() / float64(r.Unseen)
+}
+
+// ByClass groups the outs by what they make, such as flush outs or set outs.
+func (r *OutsResult) ByClass() map[HandClass][]Out {
+	groups := make(map[HandClass][]Out)
+	for _, out := range r.Outs {
+		groups[out.Class] = append(groups[out.Class], out)
+	}
+	return groups
+}
+
+// String lists the outs by class, best first, such as
+// "15 outs of 45 (33.33%): Flush 9 (2 improving the opponent), Pair 6".
+func (r *OutsResult) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d outs of %d (%.2f%%)", r.Count(), r.Unseen, r.Probability()*100)
+	groups := r.ByClass()
+	sep := ": "
+	for _, class := range AllHandClasses() {
+		outs := groups[class]
+		if len(outs) == 0 {
+			continue
+		}
+		improving := 0
+		for _, out := range outs {
+			if out.ImprovesOpponent {
+				improving++
+			}
+		}
+		fmt.Fprintf(&sb, "%s%s %d", sep, class, len(outs))
+		if improving > 0 {
+			fmt.Fprintf(&sb, " (%d improving the opponent)", improving)
+		}
+		sep = ", "
+	}
+	return sb.String()
+}
+
+// CalculateOuts returns the unseen cards that give hand, on a flop or turn
+// board, a better hand than the opponent's known hole cards when it does not
+// beat them now. Dead cards are never dealt.
+func CalculateOuts(hand []Card, board []Card, dead []Card, opponent []Card) (*OutsResult, error) {
+	if len(opponent) != 2 {
+		return nil, fmt.Errorf("opponent hand must contain exactly two cards, got %d", len(opponent))
+	}
+	if err := validateOutsInput(hand, board, dead, opponent); err != nil {
+		return nil, err
+	}
+	combos := []rangeCombo{{cards: [2]Card{opponent[0], opponent[1]}, mask: cardMask(opponent), weight: 1}}
+	return calculateOuts(hand, board, append(append([]Card{}, dead...), opponent...), combos), nil
+}
+
+// CalculateOutsAgainstRange is like CalculateOuts against an opponent
+// holding a combo of their range. The share of each out is the weight of the
+// combos it puts the hand ahead of, out of those the hand was not beating
+// and that do not hold the card.
+func CalculateOutsAgainstRange(hand []Card, board []Card, dead []Card, opponent *Range) (*OutsResult, error) {
+	if opponent == nil {
+		return nil, fmt.Errorf("opponent range is nil")
+	}
+	if err := validateOutsInput(hand, board, dead); err != nil {
+		return nil, err
+	}
+	combos := liveCombos(opponent, cardMask(hand)|cardMask(board)|cardMask(dead))
+	if len(combos) == 0 {
+		return nil, fmt.Errorf("opponent range has no combos left once the hand, board and dead cards are removed")
+	}
+	return calculateOuts(hand, board, dead, combos), nil
+}
+
+// calculateOuts does the work of the outs functions on validated input,
+// dealing every card not in hand, board or known.
+func calculateOuts(hand []Card, board []Card, known []Card, combos []rangeCombo) *OutsResult {
+	evaluator := NewEvaluator()
+
+	heroNow := evaluator.Evaluate(hand, board)
+	opponentNow := make([]int, len(combos))
+	for i := range combos {
+		opponentNow[i] = evaluator.Evaluate(combos[i].cards[:], board)
+	}
+
+	stub := &Deck{Cards: GetFullDeck()}
+	stub.Remove(hand...)
+	stub.Remove(board...)
+	stub.Remove(known...)
+
+	result := &OutsResult{Unseen: len(stub.Cards)}
+	next := append(append(make([]Card, 0, 5), board...), 0)
+	for _, card := range stub.Cards {
+		next[len(board)] = card
+		heroNext := evaluator.Evaluate(hand, next)
+		cardBit := cardMask([]Card{card})
+
+		var behind, beaten float64
+		improves := false
+		for i := range combos {
+			c := &combos[i]
+			if c.mask&cardBit != 0 || heroNow < opponentNow[i] {
+				continue
+			}
+			behind += c.weight
+			opponentNext := evaluator.Evaluate(c.cards[:], next)
+			if heroNext < opponentNext {
+				beaten += c.weight
+				if evaluator.GetRankClass(opponentNext) < evaluator.GetRankClass(opponentNow[i]) {
+					improves = true
+				}
+			}
+		}
+		if beaten > 0 {
+			result.Outs = append(result.Outs, Out{
+				Card:             card,
+				Class:            HandClass(evaluator.GetRankClass(heroNext)),
+				Share:            beaten / behind,
+				ImprovesOpponent: improves,
+			})
+		}
+	}
+	return result
+}
+
+// validateOutsInput checks the arguments shared by the outs functions.
+func validateOutsInput(hand []Card, board []Card, known ...[]Card) error {
+	if len(hand) != 2 {
+		return fmt.Errorf("hand must contain exactly two cards, got %d", len(hand))
+	}
+	if len(board) < 3 || len(board) > 4 {
+		return fmt.Errorf("board must contain 3 or 4 cards, got %d", len(board))
+	}
+	return checkCards(append([][]Card{hand, board}, known...)...)
+}
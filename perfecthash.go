@@ -0,0 +1,119 @@
+package deuces
+
+import (
+	"sort"
+)
+
+const (
+	perfectHashBits    = 13
+	perfectHashSize    = 1 << perfectHashBits
+	perfectHashBuckets = 1 << 10
+)
+
+// perfectHash maps a fixed set of prime products to hand ranks without
+// collisions. It uses hash and displace: the high bits of the mixed key pick a
+// slot and the low bits pick a bucket whose displacement is XORed into it.
+type perfectHash struct {
+	seed         uint32
+	displacement [perfectHashBuckets]uint16
+	slots        [perfectHashSize]perfectHashSlot
+}
+
+type perfectHashSlot struct {
+	key  uint32
+	rank uint16
+}
+
+// get returns the rank stored for key, or 0 if key is not in the table.
+func (ph *perfectHash) get(key uint32) int {
+	h := mix32(key ^ ph.seed)
+	slot := &ph.slots[((h>>(32-perfectHashBits))^uint32(ph.displacement[h&(perfectHashBuckets-1)]))&(perfectHashSize-1)]
+	if slot.key != key {
+		return 0
+	}
+	return int(slot.rank)
+}
+
+// build fills the table with entries, trying seeds in order until every
+// bucket can be displaced into free slots. Keys must be non-zero.
+func (ph *perfectHash) build(entries map[uint32]uint16) {
+	keys := make([]uint32, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	for seed := uint32(0); ; seed++ {
+		if ph.tryBuild(seed, keys, entries) {
+			return
+		}
+	}
+}
+
+func (ph *perfectHash) tryBuild(seed uint32, keys []uint32, entries map[uint32]uint16) bool {
+	*ph = perfectHash{seed: seed}
+
+	buckets := make([][]uint32, perfectHashBuckets)
+	for _, key := range keys {
+		h := mix32(key ^ seed)
+		b := h & (perfectHashBuckets - 1)
+		buckets[b] = append(buckets[b], key)
+	}
+
+	// place the largest buckets first while the table is still sparse
+	order := make([]int, perfectHashBuckets)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(buckets[order[i]]) > len(buckets[order[j]])
+	})
+
+	for _, b := range order {
+		bucket := buckets[b]
+		if len(bucket) == 0 {
+			break
+		}
+
+		slots := make([]uint32, len(bucket))
+		for i, key := range bucket {
+			slots[i] = mix32(key^seed) >> (32 - perfectHashBits)
+			for j := 0; j < i; j++ {
+				if slots[j] == slots[i] {
+					return false // no displacement can separate these keys
+				}
+			}
+		}
+
+		placed := false
+		for d := uint32(0); d < perfectHashSize && !placed; d++ {
+			placed = true
+			for _, s := range slots {
+				if ph.slots[s^d].key != 0 {
+					placed = false
+					break
+				}
+			}
+			if placed {
+				ph.displacement[b] = uint16(d)
+				for i, key := range bucket {
+					ph.slots[slots[i]^d] = perfectHashSlot{key: key, rank: entries[key]}
+				}
+			}
+		}
+		if !placed {
+			return false
+		}
+	}
+	return true
+}
+
+// mix32 is the MurmurHash3 finalizer.
+func mix32(h uint32) uint32 {
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
@@ -0,0 +1,413 @@
+package deuces
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NumCombos is the number of distinct two card holdings in a deck.
+const NumCombos = 1326
+
+// WeightedCombo is a pair of hole cards with the weight, between 0 and 1, at
+// which it appears in a range. The higher card comes first.
+type WeightedCombo struct {
+	Cards  [2]Card
+	Weight float64
+}
+
+// Range is a weighted set of two card holdings, written in the usual
+// notation, such as "TT+,AKs,A5s-A2s:0.5,KhQh".
+type Range struct {
+	weights [NumCombos]float64
+}
+
+var (
+	// comboIndex maps the deck indices of two different cards to a combo.
+	comboIndex [52][52]int16
+	// comboCards holds the cards of each combo, higher card first.
+	comboCards [NumCombos][2]Card
+)
+
+func init() {
+	i := int16(0)
+	for hi := 1; hi < 52; hi++ {
+		for lo := 0; lo < hi; lo++ {
+			comboIndex[hi][lo], comboIndex[lo][hi] = i, i
+			comboCards[i] = [2]Card{fullDeck[hi], fullDeck[lo]}
+			i++
+		}
+	}
+}
+
+// NewRange returns an empty range.
+func NewRange() *Range {
+	return &Range{}
+}
+
+// ParseRange parses a comma separated range such as "TT+,AKs,A5s-A2s:0.5".
+// Each part is one of:
+//
+//	AKs, AKo, AK    suited, offsuit or all combos of two ranks
+//	TT              a pocket pair
+//	TT+, A9s+, KQ+  a pair and every higher pair, or a hand and every higher kicker
+//	22-55, A2s-A5s  pairs or kickers between two hands, in either order
+//	AhKh            a specific combo
+//
+// and may end with ":weight", a number in (0, 1], to include its combos at
+// that weight. Later parts override the weight of earlier ones.
+func ParseRange(s string) (*Range, error) {
+	r := NewRange()
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if err := r.addNotation(part); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Set sets the weight of the combo made of c1 and c2. A weight of 0 removes it.
+// Two identical cards, or a value that is not a card, make no combo, so the
+// range is left as is.
+func (r *Range) Set(c1, c2 Card, weight float64) {
+	if c1 == c2 || !c1.IsValid() || !c2.IsValid() {
+		return
+	}
+	r.weights[comboIndex[c1.index()][c2.index()]] = weight
+}
+
+// Weight returns the weight of the combo made of c1 and c2, or 0 if the range
+// does not contain it or c1 and c2 make no combo.
+func (r *Range) Weight(c1, c2 Card) float64 {
+	if c1 == c2 || !c1.IsValid() || !c2.IsValid() {
+		return 0
+	}
+	return r.weights[comboIndex[c1.index()][c2.index()]]
+}
+
+// Combos returns every combo in the range with its weight.
+func (r *Range) Combos() []WeightedCombo {
+	var combos []WeightedCombo
+	for i, w := range r.weights {
+		if w > 0 {
+			combos = append(combos, WeightedCombo{Cards: comboCards[i], Weight: w})
+		}
+	}
+	return combos
+}
+
+// Count returns the number of combos in the range, whatever their weight.
+func (r *Range) Count() int {
+	n := 0
+	for _, w := range r.weights {
+		if w > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// WeightedCount returns the number of combos in the range, each counted at
+// its weight.
+func (r *Range) WeightedCount() float64 {
+	total := 0.0
+	for _, w := range r.weights {
+		total += w
+	}
+	return total
+}
+
+// Union returns the combos in either range, at the higher of their weights.
+func (r *Range) Union(other *Range) *Range {
+	u := &Range{}
+	for i := range u.weights {
+		u.weights[i] = max(r.weights[i], other.weights[i])
+	}
+	return u
+}
+
+// Intersect returns the combos in both ranges, at the lower of their weights.
+func (r *Range) Intersect(other *Range) *Range {
+	u := &Range{}
+	for i := range u.weights {
+		u.weights[i] = min(r.weights[i], other.weights[i])
+	}
+	return u
+}
+
+// Subtract returns the combos of r that are not in other.
+func (r *Range) Subtract(other *Range) *Range {
+	u := &Range{}
+	for i := range u.weights {
+		if other.weights[i] == 0 {
+			u.weights[i] = r.weights[i]
+		}
+	}
+	return u
+}
+
+// RemoveCards returns the combos of r that use none of the given cards, such
+// as the board or other players' known hole cards. Values that are not cards
+// are skipped.
+func (r *Range) RemoveCards(cards ...Card) *Range {
+	u := *r
+	for _, c := range cards {
+		if !c.IsValid() {
+			continue
+		}
+		for other := 0; other < 52; other++ {
+			if other != c.index() {
+				u.weights[comboIndex[c.index()][other]] = 0
+			}
+		}
+	}
+	return &u
+}
+
+// String returns the range in compact notation that ParseRange reads back,
+// such as "TT+,AKs,A5s-A2s:0.5,KhQh".
+func (r *Range) String() string {
+	left := r.weights
+	var parts []string
+
+	// pairs, from aces down
+	var pairWeights [13]float64
+	for rank := 12; rank >= 0; rank-- {
+		pairWeights[rank] = takeClass(&left, rank, rank, 0)
+	}
+	parts = append(parts, formatRuns(pairWeights[:], 12, func(rank int) string {
+		return string(StrRanks[rank]) + string(StrRanks[rank])
+	}, true)...)
+
+	// then every high card with its kickers, both suitedness first
+	for hi := 12; hi >= 1; hi-- {
+		var both, suited, offsuit [13]float64
+		for lo := hi - 1; lo >= 0; lo-- {
+			s := classWeight(&left, hi, lo, 's')
+			o := classWeight(&left, hi, lo, 'o')
+			if s > 0 && s == o {
+				both[lo] = takeClass(&left, hi, lo, 0)
+			}
+		}
+		for lo := hi - 1; lo >= 0; lo-- {
+			suited[lo] = takeClass(&left, hi, lo, 's')
+			offsuit[lo] = takeClass(&left, hi, lo, 'o')
+		}
+		for _, group := range []struct {
+			weights *[13]float64
+			suffix  string
+		}{{&both, ""}, {&suited, "s"}, {&offsuit, "o"}} {
+			parts = append(parts, formatRuns(group.weights[:hi], hi-1, func(lo int) string {
+				return string(StrRanks[hi]) + string(StrRanks[lo]) + group.suffix
+			}, false)...)
+		}
+	}
+
+	// and whatever single combos are left
+	for i := NumCombos - 1; i >= 0; i-- {
+		if w := left[i]; w > 0 {
+			parts = append(parts, comboCards[i][0].String()+comboCards[i][1].String()+formatWeight(w))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// Helper functions
+
+// addNotation adds the combos of one comma separated part of a range.
+func (r *Range) addNotation(part string) error {
+	notation, weight := part, 1.0
+	if i := strings.IndexByte(part, ':'); i >= 0 {
+		notation = part[:i]
+		w, err := strconv.ParseFloat(part[i+1:], 64)
+		if err != nil || w <= 0 || w > 1 {
+			return fmt.Errorf("invalid weight in range part %q: must be a number in (0, 1]", part)
+		}
+		weight = w
+	}
+
+	combos, err := expandNotation(notation)
+	if err != nil {
+		return fmt.Errorf("invalid range part %q: %w", part, err)
+	}
+	for _, c := range combos {
+		r.weights[c] = weight
+	}
+	return nil
+}
+
+// expandNotation returns the combo indices of a range part without weight.
+func expandNotation(notation string) ([]int16, error) {
+	if len(notation) == 4 && strings.ContainsRune("shdc", rune(notation[1])) {
+		c1, err := NewCard(notation[:2])
+		if err != nil {
+			return nil, err
+		}
+		c2, err := NewCard(notation[2:])
+		if err != nil {
+			return nil, err
+		}
+		if c1 == c2 {
+			return nil, fmt.Errorf("repeated card %s", c1)
+		}
+		return []int16{comboIndex[c1.index()][c2.index()]}, nil
+	}
+
+	if from, to, ok := strings.Cut(notation, "-"); ok {
+		hi1, lo1, suit1, err := parseHandClass(from)
+		if err != nil {
+			return nil, err
+		}
+		hi2, lo2, suit2, err := parseHandClass(to)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case hi1 == lo1 && hi2 == lo2:
+			return pairCombos(min(hi1, hi2), max(hi1, hi2)), nil
+		case hi1 != lo1 && hi1 == hi2 && lo2 != hi2 && suit1 == suit2:
+			return kickerCombos(hi1, min(lo1, lo2), max(lo1, lo2), suit1), nil
+		default:
+			return nil, fmt.Errorf("both ends must be pairs, or share their first rank and suitedness")
+		}
+	}
+
+	plus := strings.HasSuffix(notation, "+")
+	hi, lo, suit, err := parseHandClass(strings.TrimSuffix(notation, "+"))
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case hi == lo && plus:
+		return pairCombos(hi, 12), nil
+	case hi == lo:
+		return pairCombos(hi, hi), nil
+	case plus:
+		return kickerCombos(hi, lo, hi-1, suit), nil
+	default:
+		return kickerCombos(hi, lo, lo, suit), nil
+	}
+}
+
+// parseHandClass parses a pair such as "TT" or two ranks with an optional
+// 's' or 'o' suffix, such as "AKs", with the higher rank first.
+func parseHandClass(s string) (hi, lo int, suit byte, err error) {
+	if len(s) != 2 && len(s) != 3 {
+		return 0, 0, 0, fmt.Errorf("unrecognised hand %q", s)
+	}
+	hi, ok1 := CharRankToIntRank[rune(s[0])]
+	lo, ok2 := CharRankToIntRank[rune(s[1])]
+	if !ok1 || !ok2 {
+		return 0, 0, 0, fmt.Errorf("unrecognised hand %q", s)
+	}
+	if len(s) == 3 {
+		suit = s[2]
+		if (suit != 's' && suit != 'o') || hi == lo {
+			return 0, 0, 0, fmt.Errorf("unrecognised hand %q", s)
+		}
+	}
+	if lo > hi {
+		hi, lo = lo, hi
+	}
+	return hi, lo, suit, nil
+}
+
+// pairCombos returns every pocket pair from rank from to rank to.
+func pairCombos(from, to int) []int16 {
+	var combos []int16
+	for rank := from; rank <= to; rank++ {
+		combos = append(combos, classCombos(rank, rank, 0)...)
+	}
+	return combos
+}
+
+// kickerCombos returns hi with every kicker from lo to top.
+func kickerCombos(hi, lo, top int, suit byte) []int16 {
+	var combos []int16
+	for kicker := lo; kicker <= top; kicker++ {
+		combos = append(combos, classCombos(hi, kicker, suit)...)
+	}
+	return combos
+}
+
+// classCombos returns the combos of two ranks: suited for 's', offsuit for
+// 'o' and all of them otherwise.
+func classCombos(hi, lo int, suit byte) []int16 {
+	var combos []int16
+	for s1 := 0; s1 < 4; s1++ {
+		for s2 := 0; s2 < 4; s2++ {
+			c1, c2 := hi*4+s1, lo*4+s2
+			if c1 <= c2 && hi == lo {
+				continue
+			}
+			if (suit == 's' && s1 != s2) || (suit == 'o' && s1 == s2) {
+				continue
+			}
+			combos = append(combos, comboIndex[c1][c2])
+		}
+	}
+	return combos
+}
+
+// classWeight returns the weight shared by every combo of a hand class, or 0
+// if they are not all present at the same weight.
+func classWeight(weights *[NumCombos]float64, hi, lo int, suit byte) float64 {
+	combos := classCombos(hi, lo, suit)
+	w := weights[combos[0]]
+	for _, c := range combos[1:] {
+		if weights[c] != w {
+			return 0
+		}
+	}
+	return w
+}
+
+// takeClass removes a hand class from weights if it is complete, returning
+// its weight, or 0 if it is not.
+func takeClass(weights *[NumCombos]float64, hi, lo int, suit byte) float64 {
+	w := classWeight(weights, hi, lo, suit)
+	if w > 0 {
+		for _, c := range classCombos(hi, lo, suit) {
+			weights[c] = 0
+		}
+	}
+	return w
+}
+
+// formatRuns writes runs of equal non-zero weights, scanning down from top.
+// A run reaching top is written with "+", except for a lone non-pair.
+func formatRuns(weights []float64, top int, name func(int) string, pairs bool) []string {
+	var parts []string
+	for i := len(weights) - 1; i >= 0; {
+		w := weights[i]
+		if w == 0 {
+			i--
+			continue
+		}
+		j := i
+		for j > 0 && weights[j-1] == w {
+			j--
+		}
+		switch {
+		case i == top && (i != j || pairs) && !(pairs && j == top):
+			parts = append(parts, name(j)+"+"+formatWeight(w))
+		case i == j:
+			parts = append(parts, name(i)+formatWeight(w))
+		default:
+			parts = append(parts, name(i)+"-"+name(j)+formatWeight(w))
+		}
+		i = j - 1
+	}
+	return parts
+}
+
+func formatWeight(w float64) string {
+	if w == 1 {
+		return ""
+	}
+	return ":" + strconv.FormatFloat(w, 'g', -1, 64)
+}
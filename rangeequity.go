@@ -0,0 +1,382 @@
+package deuces
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+)
+
+// rangeCombo is a combo of a player's range that can still be dealt.
+// mask has one bit per card, indexed by position in an unshuffled deck.
+type rangeCombo struct {
+	cards  [2]Card
+	mask   uint64
+	weight float64
+}
+
+// rankedCombo is a combo with its rank on a complete board.
+type rankedCombo struct {
+	mask   uint64
+	weight float64
+	rank   int
+}
+
+// EnumerateRangeEquity computes the exact equity of a hero range against one
+// or more villain ranges, dealing every compatible set of combos and every
+// completion of the board. Combos that share a card with the board, the dead
+// cards or another player's combo are never dealt together, and each deal
+// counts in proportion to the product of its combos' weights. The hero comes
+// first in the result, followed by the villains in order.
+func EnumerateRangeEquity(hero *Range, villains []*Range, board []Card, dead []Card) (*EquityResult, error) {
+	players, err := rangeEquityPlayers(hero, villains, board, dead)
+	if err != nil {
+		return nil, err
+	}
+	return enumerateRangeEquity(players, board, dead), nil
+}
+
+// EstimateRangeEquity estimates the equity of a hero range against one or
+// more villain ranges using Monte Carlo simulation. Each iteration draws a
+// combo for every player in proportion to its weight, redrawing the whole
+// deal when two combos share a card, then completes the board.
+func EstimateRangeEquity(hero *Range, villains []*Range, board []Card, dead []Card, iterations int) (*EquityResult, error) {
+	return EstimateRangeEquityWithOptions(context.Background(), hero, villains, board, dead, iterations, SimulationOptions{})
+}
+
+// EstimateRangeEquityWithOptions is like EstimateRangeEquity, with the seed,
+// workers, random source and target precision set by opts. It stops promptly
+// once ctx is done, returning the results of the iterations run so far
+// together with ctx.Err().
+func EstimateRangeEquityWithOptions(ctx context.Context, hero *Range, villains []*Range, board []Card, dead []Card, iterations int, opts SimulationOptions) (*EquityResult, error) {
+	players, err := rangeEquityPlayers(hero, villains, board, dead)
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.validateIterations(iterations); err != nil {
+		return nil, err
+	}
+	if err := opts.checkHoldem(); err != nil {
+		return nil, err
+	}
+	return estimateRangeEquity(ctx, players, board, dead, iterations, opts)
+}
+
+// RangeEquity computes the equity of a hero range against one or more
+// villain ranges, enumerating every showdown when there are at most
+// ExactEnumerationLimit of them and otherwise running a Monte Carlo
+// simulation of the given number of iterations. EquityResult.Exact reports
+// which one was used.
+func RangeEquity(hero *Range, villains []*Range, board []Card, dead []Card, iterations int) (*EquityResult, error) {
+	players, err := rangeEquityPlayers(hero, villains, board, dead)
+	if err != nil {
+		return nil, err
+	}
+
+	count := binomial(len(fullDeck)-len(board)-len(dead), 5-len(board))
+	for _, combos := range players {
+		count *= float64(len(combos))
+	}
+	if count <= ExactEnumerationLimit {
+		return enumerateRangeEquity(players, board, dead), nil
+	}
+	if err := (SimulationOptions{}).validateIterations(iterations); err != nil {
+		return nil, err
+	}
+	return estimateRangeEquity(context.Background(), players, board, dead, iterations, SimulationOptions{})
+}
+
+// enumerateRangeEquity does the work of EnumerateRangeEquity on validated
+// input. Boards are shared out between one worker per CPU.
+func enumerateRangeEquity(players [][]rangeCombo, board []Card, dead []Card) *EquityResult {
+	evaluator := NewEvaluator()
+
+	stub := &Deck{Cards: GetFullDeck()}
+	stub.Remove(board...)
+	stub.Remove(dead...)
+
+	boards := completeBoards(board, stub.Cards)
+
+	tallies := make([]equityTally, runtime.NumCPU())
+	ranks := make([][]int, runtime.NumCPU())
+	live := make([][][]rankedCombo, runtime.NumCPU())
+	for w := range tallies {
+		tallies[w] = newEquityTally(len(players))
+		ranks[w] = make([]int, len(players))
+		live[w] = make([][]rankedCombo, len(players))
+	}
+
+	parallelFor(len(boards), func(worker, i int) {
+		fullBoard := boards[i][:]
+		boardMask := cardMask(fullBoard)
+		for p, combos := range players {
+			ranked := live[worker][p][:0]
+			for _, c := range combos {
+				if c.mask&boardMask == 0 {
+					ranked = append(ranked, rankedCombo{
+						mask:   c.mask,
+						weight: c.weight,
+						rank:   evaluator.Evaluate(c.cards[:], fullBoard),
+					})
+				}
+			}
+			live[worker][p] = ranked
+		}
+		enumerateDeals(live[worker], 0, boardMask, 1, ranks[worker], &tallies[worker])
+	})
+
+	total := newEquityTally(len(players))
+	for _, tally := range tallies {
+		total.add(tally)
+	}
+	return total.result(true)
+}
+
+// enumerateDeals records a showdown for every way to give each player from
+// p onwards one of their combos without reusing a card.
+func enumerateDeals(live [][]rankedCombo, p int, used uint64, weight float64, ranks []int, tally *equityTally) {
+	if p == len(live) {
+		tally.record(ranks, weight)
+		return
+	}
+	for _, c := range live[p] {
+		if c.mask&used != 0 {
+			continue
+		}
+		ranks[p] = c.rank
+		enumerateDeals(live, p+1, used|c.mask, weight*c.weight, ranks, tally)
+	}
+}
+
+// estimateRangeEquity does the work of EstimateRangeEquityWithOptions on
+// validated input.
+func estimateRangeEquity(ctx context.Context, players [][]rangeCombo, board []Card, dead []Card, iterations int, opts SimulationOptions) (*EquityResult, error) {
+	evaluator := NewEvaluator()
+
+	knownMask := cardMask(board) | cardMask(dead)
+
+	cumulative := cumulativeWeights(players)
+
+	samplers := make([]*sampler, opts.workers())
+	for w := range samplers {
+		samplers[w] = newSampler(board, dead)
+	}
+
+	newTally := func() equityTally { return newEquityTally(len(players)) }
+	total, err := simulateBatches(ctx, iterations, opts, newTally, func(worker int, rng *rand.Rand, workerIter int, batch *equityTally) {
+		deck := samplers[worker]
+		deck.reset()
+
+		ranks := make([]int, len(players))
+		hands := make([][2]Card, len(players))
+		var currentBoard [5]Card
+		copy(currentBoard[:], board)
+		for j := 0; j < workerIter; {
+			// Draw a combo for every player, starting over on a conflict
+			used, ok := drawCombos(players, cumulative, rng, knownMask, hands)
+			if !ok {
+				continue
+			}
+
+			// Complete the board around the combos
+			deck.newDeal()
+			copy(currentBoard[len(board):], deck.drawExcluding(rng, 5-len(board), used))
+
+			for p := range hands {
+				ranks[p] = evaluator.Evaluate(hands[p][:], currentBoard[:])
+			}
+			batch.record(ranks, 1)
+			j++
+		}
+	}, nil)
+	return total.result(false), err
+}
+
+// EstimateWinProbabilityAgainstRanges estimates the hero's chances like
+// EstimateWinProbabilityWithOptions, but deals each opponent's hole cards
+// from their range instead of uniformly at random. A nil range stands for
+// any two cards. Each iteration draws a combo for every opponent with a
+// range in proportion to its weight, redrawing them all when two share a
+// card, then deals the board and the other opponents' cards from the rest.
+func EstimateWinProbabilityAgainstRanges(ctx context.Context, hand []Card, board []Card, dead []Card, opponents []*Range, iterations int, opts SimulationOptions) (*HandResult, error) {
+	if err := validateWinProbabilityInput(hand, board, dead, len(opponents)); err != nil {
+		return nil, err
+	}
+	if err := opts.validateIterations(iterations); err != nil {
+		return nil, err
+	}
+	if err := opts.checkHoldem(); err != nil {
+		return nil, err
+	}
+
+	knownMask := cardMask(hand) | cardMask(board) | cardMask(dead)
+	var ranged [][]rangeCombo
+	for i, r := range opponents {
+		if r == nil {
+			continue
+		}
+		combos := liveCombos(r, knownMask)
+		if len(combos) == 0 {
+			return nil, fmt.Errorf("range of opponent %d has no combos left once the hand, board and dead cards are removed", i+1)
+		}
+		ranged = append(ranged, combos)
+	}
+	if !canDeal(ranged, 0, knownMask) {
+		return nil, fmt.Errorf("ranges have no combos that can be dealt together")
+	}
+	cumulative := cumulativeWeights(ranged)
+
+	evaluator := NewEvaluator()
+	samplers := make([]*sampler, opts.workers())
+	for w := range samplers {
+		samplers[w] = newSampler(hand, board, dead)
+	}
+
+	return simulateShowdowns(ctx, iterations, opts, func(worker int, rng *rand.Rand, workerIter int, tally *showdownTally) {
+		deck := samplers[worker]
+		deck.reset()
+
+		hands := make([][2]Card, len(ranged))
+		var currentBoard [5]Card
+		copy(currentBoard[:], board)
+		for j := 0; j < workerIter; {
+			used, ok := drawCombos(ranged, cumulative, rng, knownMask, hands)
+			if !ok {
+				continue
+			}
+
+			deck.newDeal()
+			copy(currentBoard[len(board):], deck.drawExcluding(rng, 5-len(board), used))
+			heroRank := evaluator.Evaluate(hand, currentBoard[:])
+
+			// Opponents with ranges first, then the random ones
+			lost := false
+			tiedOpponents := 0
+			for k := 0; k < len(opponents) && !lost; k++ {
+				var rank int
+				if k < len(ranged) {
+					rank = evaluator.Evaluate(hands[k][:], currentBoard[:])
+				} else {
+					rank = evaluator.Evaluate(deck.drawExcluding(rng, 2, used), currentBoard[:])
+				}
+				if rank < heroRank {
+					lost = true
+				} else if rank == heroRank {
+					tiedOpponents++
+				}
+			}
+			tally.record(lost, tiedOpponents)
+			j++
+		}
+	})
+}
+
+// liveCombos returns the combos of r that share no card with knownMask.
+func liveCombos(r *Range, knownMask uint64) []rangeCombo {
+	var combos []rangeCombo
+	for i, w := range r.weights {
+		cards := comboCards[i]
+		mask := cardMask(cards[:])
+		if w > 0 && mask&knownMask == 0 {
+			combos = append(combos, rangeCombo{cards: cards, mask: mask, weight: w})
+		}
+	}
+	return combos
+}
+
+// cumulativeWeights returns the running sums of the weights of every
+// player's combos, to draw them in proportion to their weights.
+func cumulativeWeights(players [][]rangeCombo) [][]float64 {
+	cumulative := make([][]float64, len(players))
+	for p, combos := range players {
+		sum := 0.0
+		cumulative[p] = make([]float64, len(combos))
+		for i, c := range combos {
+			sum += c.weight
+			cumulative[p][i] = sum
+		}
+	}
+	return cumulative
+}
+
+// drawCombos draws a combo for every player into hands and returns the mask
+// of the cards used along with those in used. It reports false if two
+// combos, or a combo and used, share a card.
+func drawCombos(players [][]rangeCombo, cumulative [][]float64, rng *rand.Rand, used uint64, hands [][2]Card) (uint64, bool) {
+	for p, combos := range players {
+		c := combos[drawWeighted(cumulative[p], rng)]
+		if c.mask&used != 0 {
+			return used, false
+		}
+		used |= c.mask
+		hands[p] = c.cards
+	}
+	return used, true
+}
+
+// drawWeighted returns a random index into cumulative, a running sum of
+// positive weights, with each index drawn in proportion to its weight.
+func drawWeighted(cumulative []float64, rng *rand.Rand) int {
+	x := rng.Float64() * cumulative[len(cumulative)-1]
+	return sort.Search(len(cumulative)-1, func(i int) bool { return cumulative[i] > x })
+}
+
+// rangeEquityPlayers checks the arguments shared by the functions that
+// compute range equity and returns the combos each player can be dealt,
+// hero first.
+func rangeEquityPlayers(hero *Range, villains []*Range, board []Card, dead []Card) ([][]rangeCombo, error) {
+	if len(villains) < 1 || len(villains) > MaxOpponents {
+		return nil, fmt.Errorf("number of villain ranges must be between 1 and %d, got %d", MaxOpponents, len(villains))
+	}
+	if len(board) > 5 {
+		return nil, fmt.Errorf("board must contain between 0 and 5 cards, got %d", len(board))
+	}
+	if err := checkCards(board, dead); err != nil {
+		return nil, err
+	}
+	if err := checkDealable(len(board)+len(dead)+2*(len(villains)+1), 5-len(board)); err != nil {
+		return nil, err
+	}
+
+	knownMask := cardMask(board) | cardMask(dead)
+	ranges := append([]*Range{hero}, villains...)
+	players := make([][]rangeCombo, len(ranges))
+	for p, r := range ranges {
+		if r == nil {
+			return nil, fmt.Errorf("range %d is nil", p+1)
+		}
+		players[p] = liveCombos(r, knownMask)
+		if len(players[p]) == 0 {
+			return nil, fmt.Errorf("range %d has no combos left once the board and dead cards are removed", p+1)
+		}
+	}
+	if !canDeal(players, 0, knownMask) {
+		return nil, fmt.Errorf("ranges have no combos that can be dealt together")
+	}
+	return players, nil
+}
+
+// canDeal reports whether every player from p onwards can be given one of
+// their combos without reusing a card.
+func canDeal(players [][]rangeCombo, p int, used uint64) bool {
+	if p == len(players) {
+		return true
+	}
+	for _, c := range players[p] {
+		if c.mask&used == 0 && canDeal(players, p+1, used|c.mask) {
+			return true
+		}
+	}
+	return false
+}
+
+// cardMask returns a mask with one bit set per card, indexed by position in
+// an unshuffled deck.
+func cardMask(cards []Card) uint64 {
+	var mask uint64
+	for _, c := range cards {
+		mask |= 1 << uint(c.index())
+	}
+	return mask
+}
@@ -0,0 +1,150 @@
+package deuces
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HandRank is the rank of a five card hand, from 1 (royal flush) to 7462
+// (seven high). Lower ranks are better.
+type HandRank int
+
+// HandClass is the category of a hand, such as a flush or two pair. Better
+// classes have lower values, matching Evaluator.GetRankClass.
+type HandClass int
+
+const (
+	StraightFlush HandClass = iota + 1
+	FourOfAKind
+	FullHouse
+	Flush
+	Straight
+	ThreeOfAKind
+	TwoPair
+	Pair
+	HighCard
+)
+
+// AllHandClasses returns every hand class, from best to worst.
+func AllHandClasses() []HandClass {
+	return []HandClass{StraightFlush, FourOfAKind, FullHouse, Flush, Straight, ThreeOfAKind, TwoPair, Pair, HighCard}
+}
+
+// IsValid reports whether r is between 1 and MaxHighCard.
+func (r HandRank) IsValid() bool {
+	return r >= 1 && r <= MaxHighCard
+}
+
+// Class returns the class of the hand, or 0 if the rank is not valid.
+func (r HandRank) Class() HandClass {
+	switch {
+	case !r.IsValid():
+		return 0
+	case r <= MaxStraightFlush:
+		return StraightFlush
+	case r <= MaxFourOfAKind:
+		return FourOfAKind
+	case r <= MaxFullHouse:
+		return FullHouse
+	case r <= MaxFlush:
+		return Flush
+	case r <= MaxStraight:
+		return Straight
+	case r <= MaxThreeOfAKind:
+		return ThreeOfAKind
+	case r <= MaxTwoPair:
+		return TwoPair
+	case r <= MaxPair:
+		return Pair
+	default:
+		return HighCard
+	}
+}
+
+// Beats reports whether r is a strictly better hand than other.
+func (r HandRank) Beats(other HandRank) bool {
+	return r < other
+}
+
+// Percentile returns the share of the 7462 distinct hand ranks that r beats or
+// ties, from 1/7462 for the worst high card to 1.0 for a royal flush.
+func (r HandRank) Percentile() float64 {
+	return float64(MaxHighCard-r+1) / float64(MaxHighCard)
+}
+
+// String returns the rank followed by its class, such as "1609 (Straight)".
+func (r HandRank) String() string {
+	if !r.IsValid() {
+		return fmt.Sprintf("HandRank(%d)", int(r))
+	}
+	return fmt.Sprintf("%d (%s)", int(r), r.Class())
+}
+
+// MarshalText encodes the rank as a decimal number.
+func (r HandRank) MarshalText() ([]byte, error) {
+	if !r.IsValid() {
+		return nil, fmt.Errorf("invalid hand rank: %d", int(r))
+	}
+	return strconv.AppendInt(nil, int64(r), 10), nil
+}
+
+// UnmarshalText decodes a rank encoded by MarshalText.
+func (r *HandRank) UnmarshalText(text []byte) error {
+	v, err := strconv.Atoi(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid hand rank %q", text)
+	}
+	if !HandRank(v).IsValid() {
+		return fmt.Errorf("invalid hand rank: %d", v)
+	}
+	*r = HandRank(v)
+	return nil
+}
+
+// MarshalJSON encodes the rank as a JSON number.
+func (r HandRank) MarshalJSON() ([]byte, error) {
+	return r.MarshalText()
+}
+
+// UnmarshalJSON decodes a rank from a JSON number.
+func (r *HandRank) UnmarshalJSON(data []byte) error {
+	var v json.Number
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("invalid hand rank %s", data)
+	}
+	return r.UnmarshalText([]byte(v))
+}
+
+// IsValid reports whether c is one of the nine hand classes.
+func (c HandClass) IsValid() bool {
+	return c >= StraightFlush && c <= HighCard
+}
+
+// String returns the name of the class, such as "Full House".
+func (c HandClass) String() string {
+	if !c.IsValid() {
+		return fmt.Sprintf("HandClass(%d)", int(c))
+	}
+	return RankClassToString[int(c)]
+}
+
+// MarshalText encodes the class as its name.
+func (c HandClass) MarshalText() ([]byte, error) {
+	if !c.IsValid() {
+		return nil, fmt.Errorf("invalid hand class: %d", int(c))
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText decodes a class from its name, ignoring case.
+func (c *HandClass) UnmarshalText(text []byte) error {
+	for _, class := range AllHandClasses() {
+		if strings.EqualFold(class.String(), string(text)) {
+			*c = class
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid hand class %q", text)
+}
@@ -0,0 +1,152 @@
+package deuces
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// fiveCardHand is a five card hand as the number of cards of each rank, and
+// whether they are suited.
+type fiveCardHand struct {
+	counts [13]int
+	suited bool
+}
+
+// handKey orders five card hands for a ranking: a lower key is a better hand.
+// It also returns the hand's class.
+type handKey func(h fiveCardHand) (key int, class int)
+
+// rankedHand is a five card hand with its place in a ranking.
+type rankedHand struct {
+	fiveCardHand
+	rank, class int
+}
+
+// rankFiveCardHands ranks every multiset of five ranks, and every suited set
+// of five different ranks, from the rank lowest up, by key. Ranks start at 1
+// for the best hand, and hands with equal keys share a rank.
+func rankFiveCardHands(key handKey, lowest int) []rankedHand {
+	type keyed struct {
+		rankedHand
+		key int
+	}
+
+	var hands []keyed
+	var counts [13]int
+	var walk func(from, left int)
+	walk = func(from, left int) {
+		if left == 0 {
+			h := fiveCardHand{counts: counts}
+			k, c := key(h)
+			hands = append(hands, keyed{rankedHand{fiveCardHand: h, class: c}, k})
+			if groupSizes(counts)[0] == 1 {
+				h.suited = true
+				k, c = key(h)
+				hands = append(hands, keyed{rankedHand{fiveCardHand: h, class: c}, k})
+			}
+			return
+		}
+		for r := from; r < 13; r++ {
+			if counts[r] < 4 {
+				counts[r]++
+				walk(r, left-1)
+				counts[r]--
+			}
+		}
+	}
+	walk(lowest, 5)
+	sort.Slice(hands, func(i, j int) bool { return hands[i].key < hands[j].key })
+
+	ranked := make([]rankedHand, len(hands))
+	rank := 0
+	for i, h := range hands {
+		if i == 0 || h.key != hands[i-1].key {
+			rank++
+		}
+		ranked[i] = h.rankedHand
+		ranked[i].rank = rank
+	}
+	return ranked
+}
+
+// newRankedLookupTable returns a LookupTable that gives every hand its rank
+// in ranked, for rankings other than the standard one.
+func newRankedLookupTable(ranked []rankedHand) *LookupTable {
+	lt := &LookupTable{
+		FlushLookup:    make(map[int]int),
+		UnsuitedLookup: make(map[int]int),
+	}
+	paired := make(map[uint32]uint16)
+	for _, h := range ranked {
+		rankbits, product := 0, 1
+		for r, n := range h.counts {
+			if n > 0 {
+				rankbits |= 1 << r
+			}
+			product *= pow(Primes[r], n)
+		}
+		switch {
+		case bits.OnesCount(uint(rankbits)) < 5:
+			lt.UnsuitedLookup[product] = h.rank
+			paired[uint32(product)] = uint16(h.rank)
+		case h.suited:
+			lt.FlushLookup[product] = h.rank
+			lt.flushRanks[rankbits] = uint16(h.rank)
+		default:
+			lt.UnsuitedLookup[product] = h.rank
+			lt.uniqueRanks[rankbits] = uint16(h.rank)
+		}
+	}
+	lt.pairedHash.build(paired)
+	return lt
+}
+
+// groupKey packs the class and the value of each group of equal ranks, the
+// largest groups and then the highest values first, into a key that orders
+// hands of a class from the lowest values to the highest. value maps a rank
+// to its value.
+func groupKey(class int, counts [13]int, value func(rank int) int) int {
+	var byValue [13]int
+	for r, n := range counts {
+		byValue[value(r)] = n
+	}
+	key, groups := class, 0
+	for size := 4; size >= 1; size-- {
+		for v := 12; v >= 0; v-- {
+			if byValue[v] == size {
+				key = key<<4 | v
+				groups++
+			}
+		}
+	}
+	return key << (4 * (5 - groups))
+}
+
+// groupSizes returns the sizes of the groups of equal ranks, largest first.
+func groupSizes(counts [13]int) []int {
+	var sizes []int
+	for _, n := range counts {
+		if n > 0 {
+			sizes = append(sizes, n)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+	return sizes
+}
+
+// straightTop returns the highest rank of a hand of five consecutive ranks,
+// or -1 if it is not one. An ace also plays below wheel, the lowest rank of
+// the deck, unless wheel is -1.
+func straightTop(counts [13]int, wheel int) int {
+	top := 12
+	for top >= 0 && counts[top] == 0 {
+		top--
+	}
+	if top >= 4 && counts[top] == 1 && counts[top-1] == 1 && counts[top-2] == 1 && counts[top-3] == 1 && counts[top-4] == 1 {
+		return top
+	}
+	if wheel >= 0 && counts[12] == 1 && counts[wheel] == 1 && counts[wheel+1] == 1 && counts[wheel+2] == 1 && counts[wheel+3] == 1 {
+		return wheel + 3
+	}
+	return -1
+}
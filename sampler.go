@@ -0,0 +1,76 @@
+package deuces
+
+import (
+	"math/rand"
+)
+
+// sampler deals random cards from a stub deck that is built once, instead of
+// building and shuffling a whole deck for every iteration. Only the cards
+// that are dealt are shuffled, by a partial Fisher–Yates shuffle of the
+// front of the stub.
+type sampler struct {
+	initial []Card // the stub in its original order
+	stub    []Card // the stub as left by the last deal
+	dealt   int    // number of cards dealt since newDeal
+}
+
+// newSampler returns a sampler of the full deck without the known cards.
+func newSampler(known ...[]Card) *sampler {
+	return newDeckSampler(fullDeck, known...)
+}
+
+// newDeckSampler returns a sampler of deck without the known cards.
+func newDeckSampler(deck []Card, known ...[]Card) *sampler {
+	s := &sampler{initial: append([]Card(nil), deck...)}
+	for _, cards := range known {
+		for _, c := range cards {
+			for i, stubCard := range s.initial {
+				if stubCard == c {
+					s.initial = append(s.initial[:i], s.initial[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	s.stub = make([]Card, len(s.initial))
+	s.reset()
+	return s
+}
+
+// reset puts the stub back in its original order, so that what is dealt next
+// depends only on the random source and not on earlier deals.
+func (s *sampler) reset() {
+	copy(s.stub, s.initial)
+	s.dealt = 0
+}
+
+// newDeal returns every card to the stub. Its order is left as is, which is
+// as good as any other since only shuffled cards are dealt.
+func (s *sampler) newDeal() {
+	s.dealt = 0
+}
+
+// draw deals the next n random cards of the current deal. The cards are only
+// valid until the next deal.
+func (s *sampler) draw(rng *rand.Rand, n int) []Card {
+	start := s.dealt
+	for ; s.dealt < start+n; s.dealt++ {
+		j := s.dealt + rng.Intn(len(s.stub)-s.dealt)
+		s.stub[s.dealt], s.stub[j] = s.stub[j], s.stub[s.dealt]
+	}
+	return s.stub[start:s.dealt]
+}
+
+// drawExcluding is like draw, but never deals a card in used, a mask of
+// cards indexed by position in an unshuffled deck.
+func (s *sampler) drawExcluding(rng *rand.Rand, n int, used uint64) []Card {
+	start := s.dealt
+	for ; s.dealt < start+n; s.dealt++ {
+		j := s.dealt + rng.Intn(len(s.stub)-s.dealt)
+		for used&(1<<uint(s.stub[j].index())) != 0 {
+			j = s.dealt + rng.Intn(len(s.stub)-s.dealt)
+		}
+		s.stub[s.dealt], s.stub[j] = s.stub[j], s.stub[s.dealt]
+	}
+	return s.stub[start:s.dealt]
+}
@@ -0,0 +1,245 @@
+package deuces
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Short deck ranks, from 1 (royal flush) to MaxShortDeckHighCard. A flush
+// beats a full house, and A-6-7-8-9 is the lowest straight.
+const (
+	MaxShortDeckStraightFlush = 6
+	MaxShortDeckFourOfAKind   = 78
+	MaxShortDeckFlush         = 198
+	MaxShortDeckFullHouse     = 270
+	MaxShortDeckStraight      = 276
+	MaxShortDeckThreeOfAKind  = 528
+	MaxShortDeckTwoPair       = 780
+	MaxShortDeckPair          = 1284
+	MaxShortDeckHighCard      = 1404
+)
+
+// shortDeckLowestRank is the rank of the sixes, the lowest cards of a short deck.
+const shortDeckLowestRank = 4
+
+var (
+	// shortDeck is a cached slice of a short deck of cards.
+	shortDeck []Card
+)
+
+func init() {
+	for _, card := range fullDeck {
+		if card.GetRankInt() >= shortDeckLowestRank {
+			shortDeck = append(shortDeck, card)
+		}
+	}
+}
+
+// NewShortDeck creates a new shuffled short deck of 36 cards, six to ace.
+func NewShortDeck() *Deck {
+	d := &Deck{Cards: GetShortDeck()}
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	d.Shuffle(rng)
+	return d
+}
+
+// GetShortDeck returns a copy of a short deck of cards.
+func GetShortDeck() []Card {
+	deck := make([]Card, len(shortDeck))
+	copy(deck, shortDeck)
+	return deck
+}
+
+// NewShortDeckLookupTable creates a LookupTable for short deck hands.
+func NewShortDeckLookupTable() *LookupTable {
+	return newRankedLookupTable(rankFiveCardHands(shortDeckKey, shortDeckLowestRank))
+}
+
+// ShortDeckRank is the rank of a short deck hand, from 1 (royal flush) to
+// MaxShortDeckHighCard (nine high). Lower ranks are better.
+type ShortDeckRank int
+
+// IsValid reports whether r is between 1 and MaxShortDeckHighCard.
+func (r ShortDeckRank) IsValid() bool {
+	return r >= 1 && r <= MaxShortDeckHighCard
+}
+
+// Class returns the class of the hand, or 0 if the rank is not valid.
+// Classes keep their usual numbers, even though a Flush beats a FullHouse.
+func (r ShortDeckRank) Class() HandClass {
+	if !r.IsValid() {
+		return 0
+	}
+	return HandClass(shortDeckRankClass(int(r)))
+}
+
+// Beats reports whether r is a strictly better hand than other.
+func (r ShortDeckRank) Beats(other ShortDeckRank) bool {
+	return r < other
+}
+
+// Percentile returns the share of the 1404 distinct short deck hand ranks
+// that r beats or ties, from 1/1404 for the worst high card to 1.0 for a
+// royal flush.
+func (r ShortDeckRank) Percentile() float64 {
+	return float64(MaxShortDeckHighCard-r+1) / float64(MaxShortDeckHighCard)
+}
+
+// String returns the rank followed by its class, such as "146 (Flush)".
+func (r ShortDeckRank) String() string {
+	if !r.IsValid() {
+		return fmt.Sprintf("ShortDeckRank(%d)", int(r))
+	}
+	return fmt.Sprintf("%d (%s)", int(r), r.Class())
+}
+
+// ShortDeckMadeHand is the best five card hand that can be made from a short
+// deck hand and board, like MadeHand.
+type ShortDeckMadeHand struct {
+	// Cards holds the five cards in canonical order, as in MadeHand, with
+	// the ace last in A-6-7-8-9.
+	Cards       []Card
+	Rank        ShortDeckRank
+	Description string
+}
+
+// String returns the description of the hand.
+func (m *ShortDeckMadeHand) String() string {
+	return m.Description
+}
+
+// ShortDeckEvaluator evaluates short deck hands. Like Evaluator, it returns
+// ranks where lower is better, but from 1 to MaxShortDeckHighCard.
+type ShortDeckEvaluator struct {
+	evaluator *Evaluator
+}
+
+// NewShortDeckEvaluator creates an evaluator for short deck hands.
+func NewShortDeckEvaluator() *ShortDeckEvaluator {
+	return &ShortDeckEvaluator{evaluator: newShortDeckEvaluator()}
+}
+
+// newShortDeckEvaluator returns an Evaluator on the short deck scale, for
+// the simulations.
+func newShortDeckEvaluator() *Evaluator {
+	return &Evaluator{
+		lookupTable: NewShortDeckLookupTable(),
+		shortDeck:   true,
+	}
+}
+
+// Evaluate returns the rank of the best hand made of five of the hand and
+// board cards, which hold five to seven cards together, like
+// Evaluator.Evaluate.
+func (e *ShortDeckEvaluator) Evaluate(hand []Card, board []Card) int {
+	return e.evaluator.Evaluate(hand, board)
+}
+
+// EvaluateChecked evaluates a hand like Evaluate, but validates the cards
+// first, returning the same errors as Evaluator.EvaluateChecked, and an
+// *InvalidCardError for a card below a six.
+func (e *ShortDeckEvaluator) EvaluateChecked(hand []Card, board []Card) (ShortDeckRank, error) {
+	if n := len(hand) + len(board); n < 5 || n > 7 {
+		return 0, &CardCountError{Count: n}
+	}
+	if err := e.evaluator.checkCards(hand, board); err != nil {
+		return 0, err
+	}
+	return ShortDeckRank(e.Evaluate(hand, board)), nil
+}
+
+// BestHand returns the five cards that make the best hand out of hand and
+// board, like Evaluator.BestHand.
+func (e *ShortDeckEvaluator) BestHand(hand []Card, board []Card) (*ShortDeckMadeHand, error) {
+	cards, rank, description, err := e.evaluator.bestHand(hand, board)
+	if err != nil {
+		return nil, err
+	}
+	return &ShortDeckMadeHand{Cards: cards, Rank: ShortDeckRank(rank), Description: description}, nil
+}
+
+// GetRankClass returns the class of a hand given its rank, with the usual
+// class numbers, or -1 for an invalid rank.
+func (e *ShortDeckEvaluator) GetRankClass(handRank int) int {
+	return shortDeckRankClass(handRank)
+}
+
+// ClassToString converts a class returned by GetRankClass into its name.
+func (e *ShortDeckEvaluator) ClassToString(classInt int) string {
+	return RankClassToString[classInt]
+}
+
+// GetFiveCardRankPercentage scales the hand rank to the [0.0, 1.0] range.
+func (e *ShortDeckEvaluator) GetFiveCardRankPercentage(handRank int) float64 {
+	return float64(handRank) / float64(MaxShortDeckHighCard)
+}
+
+// shortDeckRankClass is GetRankClass for short deck ranks.
+func shortDeckRankClass(handRank int) int {
+	switch {
+	case handRank < 1:
+		return -1
+	case handRank <= MaxShortDeckStraightFlush:
+		return int(StraightFlush)
+	case handRank <= MaxShortDeckFourOfAKind:
+		return int(FourOfAKind)
+	case handRank <= MaxShortDeckFlush:
+		return int(Flush)
+	case handRank <= MaxShortDeckFullHouse:
+		return int(FullHouse)
+	case handRank <= MaxShortDeckStraight:
+		return int(Straight)
+	case handRank <= MaxShortDeckThreeOfAKind:
+		return int(ThreeOfAKind)
+	case handRank <= MaxShortDeckTwoPair:
+		return int(TwoPair)
+	case handRank <= MaxShortDeckPair:
+		return int(Pair)
+	case handRank <= MaxShortDeckHighCard:
+		return int(HighCard)
+	default:
+		return -1
+	}
+}
+
+// shortDeckKey orders short deck hands like high hands, except that flushes
+// come before full houses and an ace can play below the six.
+func shortDeckKey(h fiveCardHand) (int, int) {
+	sizes := groupSizes(h.counts)
+	top := -1
+	if sizes[0] == 1 {
+		top = straightTop(h.counts, shortDeckLowestRank)
+	}
+
+	// order is the place of the class, which differs from its number
+	var order int
+	var class HandClass
+	switch {
+	case top >= 0 && h.suited:
+		order, class = 1, StraightFlush
+	case sizes[0] == 4:
+		order, class = 2, FourOfAKind
+	case h.suited:
+		order, class = 3, Flush
+	case sizes[0] == 3 && sizes[1] == 2:
+		order, class = 4, FullHouse
+	case top >= 0:
+		order, class = 5, Straight
+	case sizes[0] == 3:
+		order, class = 6, ThreeOfAKind
+	case sizes[0] == 2 && sizes[1] == 2:
+		order, class = 7, TwoPair
+	case sizes[0] == 2:
+		order, class = 8, Pair
+	default:
+		order, class = 9, HighCard
+	}
+
+	// Within a class, higher cards are better, so the ranks are packed as
+	// they are and the result inverted
+	if top >= 0 {
+		return order<<20 | (1<<20 - 1 - top<<16), int(class)
+	}
+	return order<<20 | (1<<20 - 1 - groupKey(0, h.counts, func(rank int) int { return rank })), int(class)
+}
@@ -0,0 +1,370 @@
+package deuces
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// StateTable is a precomputed directed graph over partial hands, in the style
+// of the Two Plus Two evaluator. Every state is a canonical set of up to six
+// cards; following one edge per card leads from the empty hand to the rank of
+// the final five, six or seven cards, so evaluation is one array lookup per
+// card. The table holds about 32.5 million entries (130MB).
+type StateTable struct {
+	table []int32
+}
+
+const (
+	// stateRowSize is the width of a state's row: slot 0 holds the rank of
+	// five and six card states, slots 1 to 52 the edge for each card.
+	stateRowSize = 53
+	// stateTableMagic identifies files written by StateTable.WriteTo.
+	stateTableMagic = "DEUCES2P"
+)
+
+var (
+	defaultStateTable     *StateTable
+	defaultStateTableOnce sync.Once
+)
+
+// NewStateTable generates a state table. This takes several seconds, so
+// prefer NewStateTableEvaluator, which generates it once and shares it.
+func NewStateTable() *StateTable {
+	evaluator := NewEvaluator()
+
+	// state i lives at row i+1, leaving row 0 unused so that 0 is never a
+	// valid state and can mark impossible transitions
+	ids := []uint64{0}
+	states := map[uint64]int32{0: 0}
+	table := make([]int32, stateRowSize, 32487887)
+
+	for i := 0; i < len(ids); i++ {
+		id := ids[i]
+		var row [stateRowSize]int32
+		if n := stateIDLen(id); n >= 5 {
+			row[0] = int32(evaluateStateID(evaluator, id))
+		}
+
+		for card := 0; card < 52; card++ {
+			next, n, ok := addCardToStateID(id, card)
+			if !ok {
+				continue
+			}
+			if n == 7 {
+				row[card+1] = int32(evaluateStateID(evaluator, next))
+				continue
+			}
+			j, seen := states[next]
+			if !seen {
+				j = int32(len(ids))
+				ids = append(ids, next)
+				states[next] = j
+			}
+			row[card+1] = (j + 1) * stateRowSize
+		}
+		table = append(table, row[:]...)
+	}
+
+	return &StateTable{table: table}
+}
+
+// Evaluate returns the rank of five to seven cards, matching Evaluator.Evaluate.
+// It returns -1 for any other number of cards, and for a value that is not a
+// card or a card that appears twice, which have no state in the table.
+func (st *StateTable) Evaluate(hand []Card, board []Card) int {
+	n := len(hand) + len(board)
+	if n < 5 || n > 7 {
+		return -1
+	}
+
+	p := int32(stateRowSize)
+	var used uint64
+	for _, cards := range [2][]Card{hand, board} {
+		for _, c := range cards {
+			if !c.IsValid() {
+				return -1
+			}
+			bit := uint64(1) << c.index()
+			if used&bit != 0 {
+				return -1
+			}
+			used |= bit
+			p = st.table[p+int32(c.index())+1]
+		}
+	}
+	if n < 7 {
+		p = st.table[p]
+	}
+	return int(p)
+}
+
+// WriteTo writes the table in a little-endian binary format that
+// ReadStateTable can load back.
+func (st *StateTable) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var written int64
+
+	header := make([]byte, len(stateTableMagic)+8)
+	copy(header, stateTableMagic)
+	binary.LittleEndian.PutUint64(header[len(stateTableMagic):], uint64(len(st.table)))
+	n, err := bw.Write(header)
+	written += int64(n)
+	if err != nil {
+		return written, err
+	}
+
+	buf := make([]byte, 4)
+	for _, v := range st.table {
+		binary.LittleEndian.PutUint32(buf, uint32(v))
+		n, err := bw.Write(buf)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, bw.Flush()
+}
+
+// ReadStateTable reads a table written by StateTable.WriteTo.
+func ReadStateTable(r io.Reader) (*StateTable, error) {
+	br := bufio.NewReader(r)
+
+	header := make([]byte, len(stateTableMagic)+8)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, fmt.Errorf("reading state table header: %w", err)
+	}
+	if string(header[:len(stateTableMagic)]) != stateTableMagic {
+		return nil, errors.New("not a state table file")
+	}
+	size := binary.LittleEndian.Uint64(header[len(stateTableMagic):])
+	if size == 0 || size%stateRowSize != 0 || size > 1<<26 {
+		return nil, fmt.Errorf("invalid state table size %d", size)
+	}
+
+	table := make([]int32, size)
+	buf := make([]byte, 4)
+	for i := range table {
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return nil, fmt.Errorf("reading state table: %w", err)
+		}
+		table[i] = int32(binary.LittleEndian.Uint32(buf))
+	}
+	if err := checkStateTable(table); err != nil {
+		return nil, err
+	}
+	return &StateTable{table: table}, nil
+}
+
+// checkStateTable returns an error unless every entry that Evaluate can reach
+// is a rank or, before the seventh card, the offset of a row of the table,
+// so that a corrupt file cannot make Evaluate read past the table.
+func checkStateTable(table []int32) error {
+	rows := len(table) / stateRowSize
+	if rows < 2 {
+		return fmt.Errorf("invalid state table size %d", len(table))
+	}
+	for i, v := range table[:stateRowSize] {
+		if v != 0 {
+			return fmt.Errorf("invalid state table entry %d at %d", v, i)
+		}
+	}
+
+	// Walk the rows from the initial state, the number of cards of each
+	// state telling ranks from offsets; depths holds that number plus one
+	depths := make([]int8, rows)
+	depths[1] = 1
+	queue := []int{1}
+	for q := 0; q < len(queue); q++ {
+		row := queue[q]
+		depth := depths[row] - 1
+		for slot := 0; slot < stateRowSize; slot++ {
+			i := row*stateRowSize + slot
+			v := table[i]
+			if v < 0 {
+				return fmt.Errorf("invalid state table entry %d at %d", v, i)
+			}
+			if slot == 0 || depth == 6 {
+				if v > MaxHighCard {
+					return fmt.Errorf("invalid state table rank %d at %d", v, i)
+				}
+				continue
+			}
+			next := int(v) / stateRowSize
+			if int(v)%stateRowSize != 0 || next >= rows {
+				return fmt.Errorf("invalid state table offset %d at %d", v, i)
+			}
+			switch {
+			case next == 0:
+				// an impossible transition, to the empty row
+			case depths[next] == 0:
+				depths[next] = depth + 2
+				queue = append(queue, next)
+			case depths[next] != depth+2:
+				return fmt.Errorf("state table row %d reached after %d and %d cards", next, depths[next]-1, depth+1)
+			}
+		}
+	}
+	return nil
+}
+
+// NewStateTableEvaluator creates an Evaluator that ranks hands through the
+// state table. The table is generated on first use and shared by every
+// evaluator created this way.
+func NewStateTableEvaluator() *Evaluator {
+	defaultStateTableOnce.Do(func() {
+		defaultStateTable = NewStateTable()
+	})
+	return &Evaluator{
+		lookupTable: NewLookupTable(),
+		stateTable:  defaultStateTable,
+	}
+}
+
+// LoadStateTableEvaluator creates an Evaluator from a state table file saved
+// with Evaluator.SaveStateTable.
+func LoadStateTableEvaluator(path string) (*Evaluator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	st, err := ReadStateTable(f)
+	if err != nil {
+		return nil, err
+	}
+	return &Evaluator{
+		lookupTable: NewLookupTable(),
+		stateTable:  st,
+	}, nil
+}
+
+// SaveStateTable writes the evaluator's state table to path.
+func (e *Evaluator) SaveStateTable(path string) error {
+	if e.stateTable == nil {
+		return errors.New("evaluator has no state table")
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := e.stateTable.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Helper functions
+
+// A state ID packs up to six cards, one per byte and sorted in descending
+// order, as (rank+1)<<4 | suit. The suit is 1 to 4, or 0 once the card can no
+// longer be part of a flush, which lets hands that only differ in irrelevant
+// suits share a state.
+
+// addCardToStateID returns the ID of id plus card (0 to 51) and its number of
+// cards. ok is false if the card is already in the state.
+func addCardToStateID(id uint64, card int) (next uint64, n int, ok bool) {
+	var work [7]uint8
+	for ; n < 6; n++ {
+		b := uint8(id >> (8 * n))
+		if b == 0 {
+			break
+		}
+		work[n] = b
+	}
+
+	// reject the card if it is already present, or if it would be the fifth
+	// of its rank because a dropped suit hid the duplicate
+	added := uint8((card/4+1)<<4 | (card%4 + 1))
+	sameRank := 0
+	for _, b := range work[:n] {
+		if b == added {
+			return 0, 0, false
+		}
+		if b>>4 == added>>4 {
+			sameRank++
+		}
+	}
+	if sameRank == 4 {
+		return 0, 0, false
+	}
+	work[n] = added
+	n++
+
+	var suitCount [5]int
+	for _, b := range work[:n] {
+		suitCount[b&0xF]++
+	}
+
+	// with 7-n cards still to come, a suit can only make a flush if it
+	// already holds n-2 cards
+	if needSuited := n - 2; needSuited > 1 {
+		for suit := 1; suit <= 4; suit++ {
+			if suitCount[suit] < needSuited {
+				for i := range work[:n] {
+					if int(work[i]&0xF) == suit {
+						work[i] &= 0xF0
+					}
+				}
+			}
+		}
+	}
+
+	// insertion sort, descending
+	for i := 1; i < n; i++ {
+		for j := i; j > 0 && work[j] > work[j-1]; j-- {
+			work[j], work[j-1] = work[j-1], work[j]
+		}
+	}
+	for i, b := range work[:n] {
+		next |= uint64(b) << (8 * i)
+	}
+	return next, n, true
+}
+
+// stateIDLen returns the number of cards in a state ID.
+func stateIDLen(id uint64) int {
+	n := 0
+	for ; n < 8 && id>>(8*n)&0xFF != 0; n++ {
+	}
+	return n
+}
+
+// evaluateStateID ranks the five to seven cards of a state ID. Cards whose
+// suit was dropped are spread over the suits nobody is drawing to, so they
+// can neither complete nor extend a flush.
+func evaluateStateID(e *Evaluator, id uint64) int {
+	var suitCount [5]int
+	n := stateIDLen(id)
+	for i := 0; i < n; i++ {
+		suitCount[id>>(8*i)&0xF]++
+	}
+	var free [4]int
+	numFree := 0
+	for suit := 1; suit <= 4; suit++ {
+		if suitCount[suit] == 0 {
+			free[numFree] = suit
+			numFree++
+		}
+	}
+
+	var cards [7]Card
+	next := 0
+	for i := 0; i < n; i++ {
+		b := uint8(id >> (8 * i))
+		suit := int(b & 0xF)
+		if suit == 0 {
+			suit = free[next%numFree]
+			next++
+		}
+		cards[i] = fullDeck[int(b>>4-1)*4+suit-1]
+	}
+	return e.Evaluate(cards[:n], nil)
+}
@@ -0,0 +1,242 @@
+package deuces
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+)
+
+// HandStrength holds the hand strength and potential metrics of Billings and
+// Papp for a hand on the flop, turn or river. Potentials look ahead to the
+// river; on the river they are 0.
+type HandStrength struct {
+	HS              float64 // Share of opponent holdings the hand beats now, counting ties as half
+	PPot            float64 // Chance that a hand behind or tied now is ahead on the river
+	NPot            float64 // Chance that a hand ahead or tied now is behind on the river
+	EHS             float64 // Effective hand strength against Opponents: HS^n(1-NPot) + (1-HS^n)PPot
+	Opponents       int     // Number of opponents EHS is computed for
+	TotalIterations int     // Total number of samples drawn, or of holdings and runouts enumerated
+	Exact           bool    // Whether every holding and runout was enumerated rather than sampled
+}
+
+// String provides a formatted string representation of the metrics
+func (hs HandStrength) String() string {
+	source := fmt.Sprintf("from %d iterations", hs.TotalIterations)
+	if hs.Exact {
+		source = fmt.Sprintf("exact, from %d runouts", hs.TotalIterations)
+	}
+	return fmt.Sprintf("HS: %.2f%%, PPot: %.2f%%, NPot: %.2f%%, EHS: %.2f%% vs %d (%s)",
+		hs.HS*100, hs.PPot*100, hs.NPot*100, hs.EHS*100, hs.Opponents, source)
+}
+
+// Standings of the hero against an opponent holding.
+const (
+	ahead = iota
+	tied
+	behind
+)
+
+// potentialTally counts opponent holdings and runouts by the hero's standing
+// now (rows) and on the river (columns).
+type potentialTally [3][3]int
+
+func (t *potentialTally) add(other potentialTally) {
+	for now := range t {
+		for river := range t[now] {
+			t[now][river] += other[now][river]
+		}
+	}
+}
+
+// count returns the number of holdings and runouts in the tally.
+func (t *potentialTally) count() int {
+	n := 0
+	for now := range t {
+		for river := range t[now] {
+			n += t[now][river]
+		}
+	}
+	return n
+}
+
+// reset empties the tally so it can be reused.
+func (t *potentialTally) reset() {
+	*t = potentialTally{}
+}
+
+// stdErr returns the largest standard error of HS, PPot and NPot, taking
+// each potential as a proportion of the holdings it is computed over.
+func (t *potentialTally) stdErr() float64 {
+	var totals [3]int
+	for now := range t {
+		for river := range t[now] {
+			totals[now] += t[now][river]
+		}
+	}
+	n := t.count()
+	if n == 0 {
+		return math.Inf(1)
+	}
+	hs := t.result(1, false)
+	hsSq := (float64(totals[ahead]) + float64(totals[tied])/4) / float64(n)
+	stdErr := meanStdErr(hs.HS, hsSq, n)
+	if m := totals[behind] + totals[tied]; m > 0 {
+		stdErr = max(stdErr, proportionStdErr(hs.PPot, m))
+	}
+	if m := totals[ahead] + totals[tied]; m > 0 {
+		stdErr = max(stdErr, proportionStdErr(hs.NPot, m))
+	}
+	return stdErr
+}
+
+// result converts the tally into the metrics against numOpponents.
+func (t *potentialTally) result(numOpponents int, exact bool) *HandStrength {
+	var totals [3]float64
+	n := 0
+	for now := range t {
+		for river := range t[now] {
+			totals[now] += float64(t[now][river])
+			n += t[now][river]
+		}
+	}
+	hs := &HandStrength{Opponents: numOpponents, TotalIterations: n, Exact: exact}
+	if n == 0 {
+		return hs
+	}
+
+	hp := func(now, river int) float64 { return float64(t[now][river]) }
+	hs.HS = (totals[ahead] + totals[tied]/2) / float64(n)
+	if d := totals[behind] + totals[tied]/2; d > 0 {
+		hs.PPot = (hp(behind, ahead) + hp(behind, tied)/2 + hp(tied, ahead)/2) / d
+	}
+	if d := totals[ahead] + totals[tied]/2; d > 0 {
+		hs.NPot = (hp(ahead, behind) + hp(tied, behind)/2 + hp(ahead, tied)/2) / d
+	}
+	hsn := math.Pow(hs.HS, float64(numOpponents))
+	hs.EHS = hsn*(1-hs.NPot) + (1-hsn)*hs.PPot
+	return hs
+}
+
+// AnalyzeHandStrength computes the hand strength and potentials of hand on a
+// flop, turn or river board exactly, against every opponent holding and
+// every runout to the river, and the effective hand strength against
+// numOpponents. Dead cards are never dealt.
+func AnalyzeHandStrength(hand []Card, board []Card, dead []Card, numOpponents int) (*HandStrength, error) {
+	if err := validateHandStrengthInput(hand, board, dead, numOpponents); err != nil {
+		return nil, err
+	}
+
+	evaluator := NewEvaluator()
+	stub := &Deck{Cards: GetFullDeck()}
+	stub.Remove(hand...)
+	stub.Remove(board...)
+	stub.Remove(dead...)
+
+	// Rank the hero on every runout once
+	runouts := completeBoards(board, stub.Cards)
+	runoutMasks := make([]uint64, len(runouts))
+	heroRanks := make([]int, len(runouts))
+	for i := range runouts {
+		runoutMasks[i] = cardMask(runouts[i][len(board):])
+		heroRanks[i] = evaluator.Evaluate(hand, runouts[i][:])
+	}
+	heroNow := evaluator.Evaluate(hand, board)
+
+	// Then every opponent holding, on every runout it does not block
+	var holdings [][2]Card
+	for i := 0; i < len(stub.Cards); i++ {
+		for j := i + 1; j < len(stub.Cards); j++ {
+			holdings = append(holdings, [2]Card{stub.Cards[i], stub.Cards[j]})
+		}
+	}
+	tallies := make([]potentialTally, runtime.NumCPU())
+	parallelFor(len(holdings), func(worker, h int) {
+		opp := holdings[h][:]
+		mask := cardMask(opp)
+		now := standing(heroNow, evaluator.Evaluate(opp, board))
+		for i := range runouts {
+			if runoutMasks[i]&mask == 0 {
+				tallies[worker][now][standing(heroRanks[i], evaluator.Evaluate(opp, runouts[i][:]))]++
+			}
+		}
+	})
+
+	var total potentialTally
+	for w := range tallies {
+		total.add(tallies[w])
+	}
+	return total.result(numOpponents, true), nil
+}
+
+// EstimateHandStrength estimates the metrics of AnalyzeHandStrength by
+// sampling an opponent holding and a runout for each iteration, with the
+// seed, workers, random source and target precision of HS, PPot and NPot set
+// by opts. It stops promptly once ctx is done, returning the metrics of the
+// iterations run so far together with ctx.Err().
+func EstimateHandStrength(ctx context.Context, hand []Card, board []Card, dead []Card, numOpponents int, iterations int, opts SimulationOptions) (*HandStrength, error) {
+	if err := validateHandStrengthInput(hand, board, dead, numOpponents); err != nil {
+		return nil, err
+	}
+	if err := opts.validateIterations(iterations); err != nil {
+		return nil, err
+	}
+	if err := opts.checkHoldem(); err != nil {
+		return nil, err
+	}
+
+	evaluator := NewEvaluator()
+	heroNow := evaluator.Evaluate(hand, board)
+
+	samplers := make([]*sampler, opts.workers())
+	for w := range samplers {
+		samplers[w] = newSampler(hand, board, dead)
+	}
+
+	newTally := func() potentialTally { return potentialTally{} }
+	total, err := simulateBatches(ctx, iterations, opts, newTally, func(worker int, rng *rand.Rand, workerIter int, batch *potentialTally) {
+		deck := samplers[worker]
+		deck.reset()
+
+		var runout [5]Card
+		copy(runout[:], board)
+		for j := 0; j < workerIter; j++ {
+			deck.newDeal()
+			opp := deck.draw(rng, 2)
+			copy(runout[len(board):], deck.draw(rng, 5-len(board)))
+
+			now := standing(heroNow, evaluator.Evaluate(opp, board))
+			river := standing(evaluator.Evaluate(hand, runout[:]), evaluator.Evaluate(opp, runout[:]))
+			batch[now][river]++
+		}
+	}, nil)
+	return total.result(numOpponents, false), err
+}
+
+// standing returns whether the hero is ahead, tied or behind an opponent.
+func standing(heroRank, opponentRank int) int {
+	switch {
+	case heroRank < opponentRank:
+		return ahead
+	case heroRank == opponentRank:
+		return tied
+	default:
+		return behind
+	}
+}
+
+// validateHandStrengthInput checks the arguments shared by the functions
+// that compute hand strength and potential.
+func validateHandStrengthInput(hand []Card, board []Card, dead []Card, numOpponents int) error {
+	if len(board) < 3 {
+		return fmt.Errorf("board must contain between 3 and 5 cards, got %d", len(board))
+	}
+	if numOpponents < 1 {
+		return fmt.Errorf("number of opponents must be at least 1, got %d", numOpponents)
+	}
+	if err := validateWinProbabilityInput(hand, board, dead, numOpponents); err != nil {
+		return err
+	}
+	return checkDealable(len(hand)+len(board)+len(dead), 2+5-len(board))
+}
@@ -0,0 +1,143 @@
+package deuces
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// StudCards is the number of cards each player holds at the showdown of a
+// seven-card stud game.
+const StudCards = 7
+
+// StudHand is the cards a seven-card stud player has been dealt so far: two
+// down and one up on third street, one up on each of fourth to sixth street,
+// and one down on seventh street.
+type StudHand struct {
+	Down []Card // Cards dealt face down
+	Up   []Card // Cards dealt face up
+}
+
+// street returns the number of cards dealt to the hand.
+func (h StudHand) street() int {
+	return len(h.Down) + len(h.Up)
+}
+
+// check returns an error unless the hand holds the cards of a street.
+func (h StudHand) check() error {
+	if len(h.Down) < 2 || len(h.Down) > 3 {
+		return fmt.Errorf("hand must contain 2 or 3 down cards, got %d", len(h.Down))
+	}
+	if len(h.Up) < 1 || len(h.Up) > 4 {
+		return fmt.Errorf("hand must contain between 1 and 4 up cards, got %d", len(h.Up))
+	}
+	if len(h.Down) == 3 && len(h.Up) != 4 {
+		return fmt.Errorf("third down card is dealt after 4 up cards, got %d", len(h.Up))
+	}
+	return nil
+}
+
+// EstimateStudEquity estimates the all-in equity of seven-card stud players
+// by dealing each of them the rest of their seven cards, with the seed,
+// workers, random source, target precision and game set by opts. The game
+// must be Stud, Razz or StudHiLo. Every player must be on the same street.
+// Dead cards, such as the upcards of players who folded, are never dealt.
+// The deck may not run out, so there can be no more than seven players from
+// third street. It stops promptly once ctx is done, returning the results of
+// the iterations run so far together with ctx.Err(). In StudHiLo, a player
+// wins only by scooping, and ties whenever they take part of the pot.
+func EstimateStudEquity(ctx context.Context, hands []StudHand, dead []Card, iterations int, opts SimulationOptions) (*EquityResult, error) {
+	if err := validateStudInput(opts.Game, hands, dead); err != nil {
+		return nil, err
+	}
+	if err := opts.validateIterations(iterations); err != nil {
+		return nil, err
+	}
+
+	evaluator := NewEvaluator()
+	var lowball *LowballEvaluator
+	if opts.Game == Razz {
+		lowball = NewAceToFiveEvaluator()
+	}
+
+	known := append([][]Card{dead}, studCards(hands)...)
+	samplers := make([]*sampler, opts.workers())
+	for w := range samplers {
+		samplers[w] = newSampler(known...)
+	}
+
+	newTally := func() equityTally { return newEquityTally(len(hands)) }
+	total, err := simulateBatches(ctx, iterations, opts, newTally, func(worker int, rng *rand.Rand, workerIter int, batch *equityTally) {
+		deck := samplers[worker]
+		deck.reset()
+
+		// Each player's seven cards, starting with those already dealt
+		sevens := make([][StudCards]Card, len(hands))
+		for p, cards := range known[1:] {
+			copy(sevens[p][:], cards)
+		}
+		dealt := hands[0].street()
+
+		ranks := make([]int, len(hands))
+		lows := make([]int, len(hands))
+		shares := make([]float64, len(hands))
+		for j := 0; j < workerIter; j++ {
+			deck.newDeal()
+			for p := range sevens {
+				copy(sevens[p][dealt:], deck.draw(rng, StudCards-dealt))
+			}
+
+			for p := range sevens {
+				if lowball != nil {
+					ranks[p] = lowball.Evaluate(sevens[p][:], nil)
+				} else {
+					ranks[p] = evaluator.Evaluate(sevens[p][:], nil)
+				}
+			}
+			if !opts.Game.splitsPot() {
+				batch.record(ranks, 1)
+				continue
+			}
+			for p := range sevens {
+				lows[p] = opts.Game.evaluateLow(evaluator, sevens[p][:], nil)
+			}
+			splitHiLo(ranks, lows, shares)
+			batch.recordShares(shares, 1)
+		}
+	}, nil)
+	return total.result(false), err
+}
+
+// studCards returns the cards dealt to each hand, down cards first.
+func studCards(hands []StudHand) [][]Card {
+	cards := make([][]Card, len(hands))
+	for i, hand := range hands {
+		cards[i] = append(append([]Card{}, hand.Down...), hand.Up...)
+	}
+	return cards
+}
+
+// validateStudInput checks the arguments of EstimateStudEquity.
+func validateStudInput(game Game, hands []StudHand, dead []Card) error {
+	if !game.isStud() {
+		return fmt.Errorf("%v is not a stud game", game)
+	}
+	if len(hands) < 2 || len(hands) > MaxOpponents+1 {
+		return fmt.Errorf("number of players must be between 2 and %d, got %d", MaxOpponents+1, len(hands))
+	}
+	needed := 0
+	for i, hand := range hands {
+		if err := hand.check(); err != nil {
+			return fmt.Errorf("hand %d: %w", i+1, err)
+		}
+		if hand.street() != hands[0].street() {
+			return fmt.Errorf("hand %d must contain %d cards like the first, got %d", i+1, hands[0].street(), hand.street())
+		}
+		needed += StudCards - hand.street()
+	}
+	cards := studCards(hands)
+	if err := checkCards(append(cards, dead)...); err != nil {
+		return err
+	}
+	return game.checkDeck(needed, append(cards, dead)...)
+}
@@ -0,0 +1,90 @@
+package deuces_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+// mustNewCards creates cards from space separated strings such as "As Kd".
+func mustNewCards(s string) []deuces.Card {
+	var cards []deuces.Card
+	for _, f := range strings.Fields(s) {
+		cards = append(cards, mustNewCard(f))
+	}
+	return cards
+}
+
+func TestEvaluator_BestHand(t *testing.T) {
+	testCases := []struct {
+		hand, board string
+		cards       string
+		description string
+	}{
+		{"Ah Kh", "Qh Jh Th 2c 3d", "Ah Kh Qh Jh Th", "Royal Flush"},
+		{"9s 8s", "7s 6s 5s Ks Ad", "9s 8s 7s 6s 5s", "Straight Flush, Nine high"},
+		{"As 2s", "3s 4s 5s Kd Kc", "5s 4s 3s 2s As", "Straight Flush, Five high"},
+		{"9c 9d", "9h 9s Kd 2c 3c", "9s 9h 9d 9c Kd", "Four of a Kind, Nines, K kicker"},
+		{"Kc Kd", "Ks 7h 7c 7d 2c", "Ks Kd Kc 7h 7c", "Full House, Kings full of Sevens"},
+		{"Ah Jh", "8h 6h 2h 3h Kc", "Ah Jh 8h 6h 3h", "Flush, A-J-8-6-3"},
+		{"As 2d", "3c 4h 5s Kd Qc", "5s 4h 3c 2d As", "Straight, Five high"},
+		{"Th 9d", "8c 7h 6s 5d Kc", "Th 9d 8c 7h 6s", "Straight, Ten high"},
+		{"7c 7d", "7h As Kd 2c 3c", "7h 7d 7c As Kd", "Three of a Kind, Sevens, A-K kickers"},
+		{"Ac Kd", "Ah Ks Qc Qd 2c", "Ah Ac Ks Kd Qc", "Two Pair, Aces and Kings, Q kicker"},
+		{"Ac Ad", "Ks Qc 9d 4h 2c", "Ad Ac Ks Qc 9d", "Pair of Aces, K-Q-9 kickers"},
+		{"Ac 7d", "Ks Qc 9d 4h 2c", "Ac Ks Qc 9d 7d", "High Card, Ace, K-Q-9-7 kickers"},
+		{"Qs Th", "2h 2s Jc", "2s 2h Qs Jc Th", "Pair of Twos, Q-J-T kickers"},
+	}
+
+	e := deuces.NewEvaluator()
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hand, board := mustNewCards(tc.hand), mustNewCards(tc.board)
+			made, err := e.BestHand(hand, board)
+			if err != nil {
+				t.Fatalf("BestHand() error = %v", err)
+			}
+			if got := made.Description; got != tc.description {
+				t.Errorf("Description = %q, want %q", got, tc.description)
+			}
+			want := mustNewCards(tc.cards)
+			for i := range want {
+				if made.Cards[i] != want[i] {
+					t.Errorf("Cards = %v, want %v", made.Cards, want)
+					break
+				}
+			}
+			if int(made.Rank) != e.Evaluate(hand, board) {
+				t.Errorf("Rank = %d, want %d", made.Rank, e.Evaluate(hand, board))
+			}
+		})
+	}
+}
+
+func TestEvaluator_BestHandMatchesEvaluate(t *testing.T) {
+	e := deuces.NewEvaluator()
+	for _, cards := range randomHands(5000, 7) {
+		made, err := e.BestHand(cards[:2], cards[2:])
+		if err != nil {
+			t.Fatalf("BestHand(%v) error = %v", cards, err)
+		}
+		want := e.Evaluate(cards[:2], cards[2:])
+		if int(made.Rank) != want || e.Evaluate(made.Cards, nil) != want {
+			t.Fatalf("BestHand(%v) = %v (%d), want rank %d", cards, made.Cards, made.Rank, want)
+		}
+	}
+}
+
+func TestEvaluator_BestHandErrors(t *testing.T) {
+	e := deuces.NewEvaluator()
+	var countErr *deuces.CardCountError
+	if _, err := e.BestHand(mustNewCards("As Ks"), mustNewCards("Qs Js")); !errors.As(err, &countErr) {
+		t.Errorf("BestHand() error = %v, want CardCountError", err)
+	}
+	var dupErr *deuces.DuplicateCardError
+	if _, err := e.BestHand(mustNewCards("As Ks"), mustNewCards("Qs Js As")); !errors.As(err, &dupErr) {
+		t.Errorf("BestHand() error = %v, want DuplicateCardError", err)
+	}
+}
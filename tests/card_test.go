@@ -51,3 +51,28 @@ func TestCard_Getters(t *testing.T) {
 		t.Errorf("GetPrime() = %d, want 41", prime)
 	}
 }
+
+func TestCard_String(t *testing.T) {
+	for _, s := range []string{"As", "Th", "2c", "9d"} {
+		if got := mustNewCard(s).String(); got != s {
+			t.Errorf("String() = %q, want %q", got, s)
+		}
+	}
+	if got := deuces.Card(12345).String(); got != "Card(12345)" {
+		t.Errorf("String() of invalid card = %q, want %q", got, "Card(12345)")
+	}
+}
+
+func TestCard_IsValid(t *testing.T) {
+	for _, card := range deuces.GetFullDeck() {
+		if !card.IsValid() {
+			t.Errorf("IsValid(%s) = false, want true", card)
+		}
+	}
+	ace := mustNewCard("As")
+	for _, card := range []deuces.Card{0, -1, ace | 0x40, ace &^ 0x3F, ace | 8<<12} {
+		if card.IsValid() {
+			t.Errorf("IsValid(%d) = true, want false", int32(card))
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package deuces_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestConfidenceInterval(t *testing.T) {
+	low, high := deuces.ConfidenceInterval(0.5, 0.01, 0.95)
+	if math.Abs(low-0.4804) > 1e-4 || math.Abs(high-0.5196) > 1e-4 {
+		t.Errorf("ConfidenceInterval(0.5, 0.01, 0.95) = (%.4f, %.4f), want (0.4804, 0.5196)", low, high)
+	}
+	low, high = deuces.ConfidenceInterval(0.01, 0.01, 0.99)
+	if low != 0 || math.Abs(high-0.0358) > 1e-4 {
+		t.Errorf("ConfidenceInterval(0.01, 0.01, 0.99) = (%.4f, %.4f), want (0, 0.0358)", low, high)
+	}
+}
+
+func TestEstimateWinProbability_StandardErrors(t *testing.T) {
+	hand := mustNewCards("As Ks")
+
+	result, err := deuces.EstimateWinProbability(hand, nil, nil, 2, 20000)
+	if err != nil {
+		t.Fatalf("EstimateWinProbability() error = %v", err)
+	}
+	p, n := result.WinProbability, float64(result.TotalIterations)
+	if want := math.Sqrt(p * (1 - p) / n); math.Abs(result.WinStdErr-want) > 1e-12 {
+		t.Errorf("WinStdErr = %f, want %f", result.WinStdErr, want)
+	}
+	if result.TieStdErr <= 0 {
+		t.Errorf("TieStdErr = %f, want a positive error", result.TieStdErr)
+	}
+
+	exact, err := deuces.EnumerateWinProbability(hand, mustNewCards("Qs Js 2d 3c"), nil, 1)
+	if err != nil {
+		t.Fatalf("EnumerateWinProbability() error = %v", err)
+	}
+	if exact.WinStdErr != 0 || exact.TieStdErr != 0 {
+		t.Errorf("exact result has standard errors %f and %f, want 0", exact.WinStdErr, exact.TieStdErr)
+	}
+}
+
+func TestEstimateWinProbabilityWithOptions_Precision(t *testing.T) {
+	hand := mustNewCards("As Ks")
+	budget := 10000000
+
+	opts := deuces.SimulationOptions{Precision: 0.01}
+	result, err := deuces.EstimateWinProbabilityWithOptions(context.Background(), hand, nil, nil, 2, budget, opts)
+	if err != nil {
+		t.Fatalf("EstimateWinProbabilityWithOptions() error = %v", err)
+	}
+	if result.TotalIterations >= budget {
+		t.Errorf("ran the whole budget of %d iterations, want an early stop", budget)
+	}
+	if margin := 1.96 * max(result.WinStdErr, result.TieStdErr); margin > 0.01 {
+		t.Errorf("stopped with a margin of %f, want at most 0.01", margin)
+	}
+
+	// A small budget is allowed, and is run to the end when the precision
+	// cannot be reached.
+	opts = deuces.SimulationOptions{Precision: 0.0001, Confidence: 0.99}
+	result, err = deuces.EstimateWinProbabilityWithOptions(context.Background(), hand, nil, nil, 2, 500, opts)
+	if err != nil {
+		t.Fatalf("EstimateWinProbabilityWithOptions() error = %v", err)
+	}
+	if result.TotalIterations != 500 {
+		t.Errorf("TotalIterations = %d, want the budget of 500", result.TotalIterations)
+	}
+}
+
+func TestEstimateEquityWithOptions_Precision(t *testing.T) {
+	hands := [][]deuces.Card{mustNewCards("As Ks"), mustNewCards("Qd Qc"), mustNewCards("7h 7d")}
+	budget := 10000000
+
+	result, err := deuces.EstimateEquityWithOptions(context.Background(), hands, nil, nil, budget, deuces.SimulationOptions{Precision: 0.01})
+	if err != nil {
+		t.Fatalf("EstimateEquityWithOptions() error = %v", err)
+	}
+	if result.TotalIterations >= budget {
+		t.Errorf("ran the whole budget of %d iterations, want an early stop", budget)
+	}
+	for i, p := range result.Players {
+		if p.EquityStdErr <= 0 || 1.96*p.EquityStdErr > 0.01 {
+			t.Errorf("player %d EquityStdErr = %f, want positive and within the precision", i+1, p.EquityStdErr)
+		}
+	}
+}
+
+func TestEstimateHandStrength_Precision(t *testing.T) {
+	hand, board := mustNewCards("Ad Qc"), mustNewCards("3h 4c Jh")
+	budget := 10000000
+
+	result, err := deuces.EstimateHandStrength(context.Background(), hand, board, nil, 1, budget, deuces.SimulationOptions{Precision: 0.01})
+	if err != nil {
+		t.Fatalf("EstimateHandStrength() error = %v", err)
+	}
+	if result.TotalIterations >= budget {
+		t.Errorf("ran the whole budget of %d iterations, want an early stop", budget)
+	}
+	if _, err := deuces.EstimateHandStrength(context.Background(), hand, board, nil, 1, 500, deuces.SimulationOptions{Precision: 0.01}); err != nil {
+		t.Errorf("EstimateHandStrength() with a small budget error = %v", err)
+	}
+	if _, err := deuces.EstimateHandStrength(context.Background(), hand, board, nil, 1, 10000, deuces.SimulationOptions{Precision: 1.5}); err == nil {
+		t.Error("EstimateHandStrength() expected error for an invalid precision")
+	}
+}
+
+func TestEstimateEquityHistogram_Precision(t *testing.T) {
+	hand := mustNewCards("Ah Kh")
+	budget := 10000000
+
+	result, err := deuces.EstimateEquityHistogram(context.Background(), hand, mustNewCards("Qh 7h 2c"), nil, mustParseRange("QQ+,AK"), 5, budget, deuces.SimulationOptions{Precision: 0.02})
+	if err != nil {
+		t.Fatalf("EstimateEquityHistogram() error = %v", err)
+	}
+	if result.TotalIterations >= budget {
+		t.Errorf("ran the whole budget of %d iterations, want an early stop", budget)
+	}
+	if _, err := deuces.EstimateEquityHistogram(context.Background(), hand, nil, nil, nil, 5, 500, deuces.SimulationOptions{Precision: 0.02}); err != nil {
+		t.Errorf("EstimateEquityHistogram() with a small budget error = %v", err)
+	}
+}
+
+func TestSimulationOptions_Invalid(t *testing.T) {
+	hand := mustNewCards("As Ks")
+	for _, opts := range []deuces.SimulationOptions{
+		{Precision: -0.01},
+		{Precision: 1.5},
+		{Precision: 0.01, Confidence: 1},
+		{Precision: 0.01, Confidence: -0.5},
+	} {
+		if _, err := deuces.EstimateWinProbabilityWithOptions(context.Background(), hand, nil, nil, 1, 10000, opts); err == nil {
+			t.Errorf("EstimateWinProbabilityWithOptions(%+v) expected error", opts)
+		}
+	}
+	if _, err := deuces.EstimateWinProbabilityWithOptions(context.Background(), hand, nil, nil, 1, 0, deuces.SimulationOptions{Precision: 0.01}); err == nil {
+		t.Error("expected error for an empty iteration budget")
+	}
+}
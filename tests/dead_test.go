@@ -0,0 +1,93 @@
+package deuces_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestEnumerateWinProbability_DeadCards(t *testing.T) {
+	hand := mustNewCards("As Ac")
+	board := mustNewCards("Kd 7h 2c 9s")
+
+	live, err := deuces.EnumerateWinProbability(hand, board, nil, 1)
+	if err != nil {
+		t.Fatalf("EnumerateWinProbability() error = %v", err)
+	}
+	// With the other kings mucked, the opponent can no longer make a set of
+	// kings, and the river cannot pair the king.
+	dead, err := deuces.EnumerateWinProbability(hand, board, mustNewCards("Kh Ks Kc"), 1)
+	if err != nil {
+		t.Fatalf("EnumerateWinProbability() error = %v", err)
+	}
+	if want := 43 * 42 * 41 / 2; dead.TotalIterations != want {
+		t.Errorf("TotalIterations = %d, want %d", dead.TotalIterations, want)
+	}
+	if dead.WinProbability <= live.WinProbability {
+		t.Errorf("win with dead kings %f, want more than %f", dead.WinProbability, live.WinProbability)
+	}
+
+	estimate, err := deuces.EstimateWinProbabilityWithOptions(context.Background(), hand, board, mustNewCards("Kh Ks Kc"), 1, 100000, deuces.SimulationOptions{Seed: 3})
+	if err != nil {
+		t.Fatalf("EstimateWinProbabilityWithOptions() error = %v", err)
+	}
+	if d := math.Abs(estimate.Equity - dead.Equity); d > 0.01 {
+		t.Errorf("estimated equity %.4f, exact %.4f", estimate.Equity, dead.Equity)
+	}
+}
+
+func TestEnumerateEquity_DeadCards(t *testing.T) {
+	hands := [][]deuces.Card{mustNewCards("As Ks"), mustNewCards("Qd Qc")}
+	board := mustNewCards("8s 5h 2d 3c")
+
+	// No spade or ace can come, so the queens only lose to a king or a four
+	// on the river.
+	result, err := deuces.EnumerateEquity(hands, board, mustNewCards("4s 5s 6s 7s 9s Ts Js 2s 3s Ah Ad Ac"))
+	if err != nil {
+		t.Fatalf("EnumerateEquity() error = %v", err)
+	}
+	if result.TotalIterations != 32 {
+		t.Errorf("TotalIterations = %d, want 32", result.TotalIterations)
+	}
+	if want := 6.0 / 32; math.Abs(result.Players[0].Equity-want) > 1e-12 {
+		t.Errorf("AsKs equity = %f, want %f", result.Players[0].Equity, want)
+	}
+}
+
+func TestDeadCards_InputValidation(t *testing.T) {
+	hand := mustNewCards("As Ks")
+	board := mustNewCards("Qs Js 2d")
+	var dup *deuces.DuplicateCardError
+
+	for _, dead := range [][]deuces.Card{mustNewCards("As"), mustNewCards("Js"), mustNewCards("7c 7c")} {
+		if _, err := deuces.EnumerateWinProbability(hand, board, dead, 1); !errors.As(err, &dup) {
+			t.Errorf("EnumerateWinProbability(dead %v) error = %v, want *DuplicateCardError", dead, err)
+		}
+		if _, err := deuces.EstimateWinProbabilityWithOptions(context.Background(), hand, board, dead, 1, deuces.MinIterations, deuces.SimulationOptions{}); !errors.As(err, &dup) {
+			t.Errorf("EstimateWinProbabilityWithOptions(dead %v) error = %v, want *DuplicateCardError", dead, err)
+		}
+		if _, err := deuces.EnumerateEquity([][]deuces.Card{hand, mustNewCards("Qd Qc")}, board, dead); !errors.As(err, &dup) {
+			t.Errorf("EnumerateEquity(dead %v) error = %v, want *DuplicateCardError", dead, err)
+		}
+		if _, err := deuces.EstimateWinProbability(hand, board, dead, 1, deuces.MinIterations); !errors.As(err, &dup) {
+			t.Errorf("EstimateWinProbability(dead %v) error = %v, want *DuplicateCardError", dead, err)
+		}
+		if _, err := deuces.EstimateEquity([][]deuces.Card{hand, mustNewCards("Qd Qc")}, board, dead, deuces.MinIterations); !errors.As(err, &dup) {
+			t.Errorf("EstimateEquity(dead %v) error = %v, want *DuplicateCardError", dead, err)
+		}
+	}
+
+	// Too few cards left for nine opponents and the rest of the board
+	var dead []deuces.Card
+	for _, c := range deuces.GetFullDeck()[:30] {
+		if c != hand[0] && c != hand[1] && c != board[0] && c != board[1] && c != board[2] {
+			dead = append(dead, c)
+		}
+	}
+	if _, err := deuces.CalculateWinProbability(hand, board, dead, 9, deuces.MinIterations); err == nil {
+		t.Error("CalculateWinProbability() expected an error with too few cards left")
+	}
+}
@@ -0,0 +1,149 @@
+package deuces_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+// bruteForceVsOpponents walks every ordered deal of opponent hands on a
+// complete or turn board, independently of the library's enumeration.
+func bruteForceVsOpponents(t *testing.T, hand, board []deuces.Card, numOpponents int) (win, tie, loss float64) {
+	t.Helper()
+	e := deuces.NewEvaluator()
+
+	var remaining []deuces.Card
+	for _, c := range deuces.GetFullDeck() {
+		known := false
+		for _, k := range append(append([]deuces.Card{}, hand...), board...) {
+			known = known || k == c
+		}
+		if !known {
+			remaining = append(remaining, c)
+		}
+	}
+
+	var wins, ties, losses float64
+	var deal func(board []deuces.Card, used map[deuces.Card]bool, left int, heroRank int, lost bool, tied bool)
+	deal = func(board []deuces.Card, used map[deuces.Card]bool, left int, heroRank int, lost bool, tied bool) {
+		if left == 0 {
+			switch {
+			case lost:
+				losses++
+			case tied:
+				ties++
+			default:
+				wins++
+			}
+			return
+		}
+		for i, a := range remaining {
+			for _, b := range remaining[i+1:] {
+				if used[a] || used[b] {
+					continue
+				}
+				used[a], used[b] = true, true
+				rank := e.Evaluate([]deuces.Card{a, b}, board)
+				deal(board, used, left-1, heroRank, lost || rank < heroRank, tied || rank == heroRank)
+				used[a], used[b] = false, false
+			}
+		}
+	}
+
+	boards := [][]deuces.Card{board}
+	if len(board) == 4 {
+		boards = nil
+		for _, c := range remaining {
+			boards = append(boards, append(append([]deuces.Card{}, board...), c))
+		}
+	}
+	for _, b := range boards {
+		used := map[deuces.Card]bool{}
+		for _, c := range b {
+			used[c] = true
+		}
+		deal(b, used, numOpponents, e.Evaluate(hand, b), false, false)
+	}
+
+	total := wins + ties + losses
+	return wins / total, ties / total, losses / total
+}
+
+func TestEnumerateWinProbability_MatchesBruteForce(t *testing.T) {
+	testCases := []struct {
+		name         string
+		hand, board  string
+		numOpponents int
+	}{
+		{"RiverHeadsUp", "Ah Kd", "Ac 7s 7d 2c 9h", 1},
+		{"RiverTwoOpponents", "Qh Qd", "Jc 8s 4d 4c Th", 2},
+		{"TurnHeadsUp", "8s 7s", "6s 5d As Kc", 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hand, board := mustNewCards(tc.hand), mustNewCards(tc.board)
+			result, err := deuces.EnumerateWinProbability(hand, board, nil, tc.numOpponents)
+			if err != nil {
+				t.Fatalf("EnumerateWinProbability() error = %v", err)
+			}
+			if !result.Exact {
+				t.Error("Exact = false, want true")
+			}
+			win, tie, loss := bruteForceVsOpponents(t, hand, board, tc.numOpponents)
+			if math.Abs(result.WinProbability-win) > 1e-12 || math.Abs(result.TieProbability-tie) > 1e-12 || math.Abs(result.LossProbability-loss) > 1e-12 {
+				t.Errorf("got win %f tie %f loss %f, want win %f tie %f loss %f",
+					result.WinProbability, result.TieProbability, result.LossProbability, win, tie, loss)
+			}
+		})
+	}
+}
+
+func TestEnumerateWinProbability_Scenarios(t *testing.T) {
+	t.Run("RoyalFlushOnBoard", func(t *testing.T) {
+		result, err := deuces.EnumerateWinProbability(mustNewCards("2c 3d"), mustNewCards("As Ks Qs Js Ts"), nil, 1)
+		if err != nil {
+			t.Fatalf("EnumerateWinProbability() error = %v", err)
+		}
+		if result.TieProbability != 1.0 || result.TotalIterations != 990 {
+			t.Errorf("got tie %f from %d showdowns, want 1.0 from 990", result.TieProbability, result.TotalIterations)
+		}
+	})
+
+	t.Run("FlopHeadsUp", func(t *testing.T) {
+		result, err := deuces.EnumerateWinProbability(mustNewCards("As Ac"), mustNewCards("Kd 7h 2c"), nil, 1)
+		if err != nil {
+			t.Fatalf("EnumerateWinProbability() error = %v", err)
+		}
+		if result.TotalIterations != 1081*990 {
+			t.Errorf("TotalIterations = %d, want %d", result.TotalIterations, 1081*990)
+		}
+		if result.WinProbability < 0.85 || result.WinProbability > 0.95 {
+			t.Errorf("WinProbability = %f, want around 0.9", result.WinProbability)
+		}
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		if _, err := deuces.EnumerateWinProbability(mustNewCards("As"), nil, nil, 1); err == nil {
+			t.Error("expected error for a one card hand")
+		}
+	})
+}
+
+func TestCalculateWinProbability_ChoosesMethod(t *testing.T) {
+	turn, err := deuces.CalculateWinProbability(mustNewCards("As Ac"), mustNewCards("Kd 7h 2c 9s"), nil, 2, deuces.MinIterations)
+	if err != nil {
+		t.Fatalf("CalculateWinProbability() error = %v", err)
+	}
+	if !turn.Exact {
+		t.Error("turn with two opponents: Exact = false, want true")
+	}
+
+	preflop, err := deuces.CalculateWinProbability(mustNewCards("As Ac"), nil, nil, 1, 20000)
+	if err != nil {
+		t.Fatalf("CalculateWinProbability() error = %v", err)
+	}
+	if preflop.Exact || preflop.TotalIterations != 20000 {
+		t.Errorf("preflop: Exact = %v from %d, want sampled from 20000", preflop.Exact, preflop.TotalIterations)
+	}
+}
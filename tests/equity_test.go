@@ -0,0 +1,126 @@
+package deuces_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestEnumerateEquity_River(t *testing.T) {
+	board := mustNewCards("As Ks Qd 7c 2h")
+	hands := [][]deuces.Card{mustNewCards("Jc Th"), mustNewCards("Jd Ts"), mustNewCards("Ah 2c")}
+
+	result, err := deuces.EnumerateEquity(hands, board, nil)
+	if err != nil {
+		t.Fatalf("EnumerateEquity() error = %v", err)
+	}
+	if !result.Exact || result.TotalIterations != 1 {
+		t.Errorf("got Exact %v from %d boards, want exact from 1", result.Exact, result.TotalIterations)
+	}
+	for i, want := range []deuces.PlayerEquity{
+		{WinProbability: 0, TieProbability: 1, Equity: 0.5},
+		{WinProbability: 0, TieProbability: 1, Equity: 0.5},
+		{WinProbability: 0, TieProbability: 0, Equity: 0},
+	} {
+		if result.Players[i] != want {
+			t.Errorf("player %d = %+v, want %+v", i+1, result.Players[i], want)
+		}
+	}
+}
+
+func TestEnumerateEquity_AcesVersusKings(t *testing.T) {
+	result, err := deuces.EnumerateEquity([][]deuces.Card{mustNewCards("As Ah"), mustNewCards("Kd Kc")}, nil, nil)
+	if err != nil {
+		t.Fatalf("EnumerateEquity() error = %v", err)
+	}
+	if result.TotalIterations != 1712304 {
+		t.Errorf("TotalIterations = %d, want 1712304", result.TotalIterations)
+	}
+	aces, kings := result.Players[0], result.Players[1]
+	if aces.Equity < 0.81 || aces.Equity > 0.83 {
+		t.Errorf("AA vs KK equity = %.4f, want about 0.82", aces.Equity)
+	}
+	if math.Abs(aces.Equity+kings.Equity-1) > 1e-9 {
+		t.Errorf("equities sum to %f, want 1", aces.Equity+kings.Equity)
+	}
+	if aces.TieProbability != kings.TieProbability {
+		t.Errorf("tie probabilities differ: %f and %f", aces.TieProbability, kings.TieProbability)
+	}
+}
+
+func TestEstimateEquity_MatchesEnumeration(t *testing.T) {
+	hands := [][]deuces.Card{mustNewCards("As Ks"), mustNewCards("Qd Qc"), mustNewCards("7h 7d")}
+	board := mustNewCards("Qs 8s 2d")
+
+	exact, err := deuces.EnumerateEquity(hands, board, nil)
+	if err != nil {
+		t.Fatalf("EnumerateEquity() error = %v", err)
+	}
+	estimate, err := deuces.EstimateEquity(hands, board, nil, 100000)
+	if err != nil {
+		t.Fatalf("EstimateEquity() error = %v", err)
+	}
+	if estimate.Exact || estimate.TotalIterations != 100000 {
+		t.Errorf("got Exact %v from %d iterations, want sampled from 100000", estimate.Exact, estimate.TotalIterations)
+	}
+	for i := range hands {
+		if d := math.Abs(exact.Players[i].Equity - estimate.Players[i].Equity); d > 0.01 {
+			t.Errorf("player %d equity: estimate %.4f, exact %.4f", i+1, estimate.Players[i].Equity, exact.Players[i].Equity)
+		}
+	}
+}
+
+func TestEquity_InputValidation(t *testing.T) {
+	testCases := []struct {
+		name  string
+		hands [][]deuces.Card
+		board []deuces.Card
+	}{
+		{"One player", [][]deuces.Card{mustNewCards("As Ks")}, nil},
+		{"Short hand", [][]deuces.Card{mustNewCards("As Ks"), mustNewCards("Qd")}, nil},
+		{"Board too large", [][]deuces.Card{mustNewCards("As Ks"), mustNewCards("Qd Qc")}, mustNewCards("2c 3c 4c 5c 6c 7c")},
+		{"Shared card", [][]deuces.Card{mustNewCards("As Ks"), mustNewCards("As Qc")}, nil},
+		{"Card on board", [][]deuces.Card{mustNewCards("As Ks"), mustNewCards("Qd Qc")}, mustNewCards("Qd 2c 3c")},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := deuces.EnumerateEquity(tc.hands, tc.board, nil); err == nil {
+				t.Error("EnumerateEquity() expected error")
+			}
+			if _, err := deuces.EstimateEquity(tc.hands, tc.board, nil, deuces.MinIterations); err == nil {
+				t.Error("EstimateEquity() expected error")
+			}
+		})
+	}
+}
+
+func TestEstimateEquityWithOptions_Reproducible(t *testing.T) {
+	hands := [][]deuces.Card{mustNewCards("As Ks"), mustNewCards("Qd Qc")}
+	hero, villain := mustParseRange("AK"), mustParseRange("QQ+")
+
+	equity := func(workers int) (*deuces.EquityResult, *deuces.EquityResult) {
+		t.Helper()
+		opts := deuces.SimulationOptions{Seed: 1, Workers: workers}
+		known, err := deuces.EstimateEquityWithOptions(context.Background(), hands, nil, nil, 5000, opts)
+		if err != nil {
+			t.Fatalf("EstimateEquityWithOptions() error = %v", err)
+		}
+		ranges, err := deuces.EstimateRangeEquityWithOptions(context.Background(), hero, []*deuces.Range{villain}, nil, nil, 5000, opts)
+		if err != nil {
+			t.Fatalf("EstimateRangeEquityWithOptions() error = %v", err)
+		}
+		return known, ranges
+	}
+
+	known1, ranges1 := equity(1)
+	known2, ranges2 := equity(5)
+	if !reflect.DeepEqual(known1, known2) {
+		t.Errorf("known hands: %v with 1 worker, %v with 5", known1, known2)
+	}
+	if !reflect.DeepEqual(ranges1, ranges2) {
+		t.Errorf("ranges: %v with 1 worker, %v with 5", ranges1, ranges2)
+	}
+}
@@ -1,6 +1,7 @@
 package deuces_test
 
 import (
+	"errors"
 	"github.com/gregory-chatelier/go-deuces"
 	"testing"
 )
@@ -225,3 +226,107 @@ func TestEvaluator_GetFiveCardRankPercentage(t *testing.T) {
 		t.Errorf("Worst hand percentage = %f, want %f", percentage, 1.0)
 	}
 }
+
+func TestEvaluator_SevenCardsMatchBestFiveCardSubset(t *testing.T) {
+	e := deuces.NewEvaluator()
+	for _, cards := range randomHands(2000, 7) {
+		want := deuces.MaxHighCard
+		subset := make([]deuces.Card, 0, 5)
+		for skip1 := 0; skip1 < 7; skip1++ {
+			for skip2 := skip1 + 1; skip2 < 7; skip2++ {
+				subset = subset[:0]
+				for i, c := range cards {
+					if i != skip1 && i != skip2 {
+						subset = append(subset, c)
+					}
+				}
+				if rank := e.Evaluate(subset, nil); rank < want {
+					want = rank
+				}
+			}
+		}
+		if got := e.Evaluate(cards[:2], cards[2:]); got != want {
+			t.Errorf("Evaluate(%v) = %d, want %d", cards, got, want)
+		}
+	}
+}
+
+func TestEvaluator_EvaluateDoesNotModifyInput(t *testing.T) {
+	e := deuces.NewEvaluator()
+	backing := []deuces.Card{mustNewCard("As"), mustNewCard("Ks"), mustNewCard("2c"), mustNewCard("2d")}
+	hand := backing[:2]
+	board := []deuces.Card{mustNewCard("Qs"), mustNewCard("Js"), mustNewCard("Ts"), mustNewCard("3h"), mustNewCard("4h")}
+
+	e.Evaluate(hand, board)
+
+	if backing[2] != mustNewCard("2c") || backing[3] != mustNewCard("2d") {
+		t.Errorf("Evaluate wrote past the end of hand: %v", backing)
+	}
+}
+
+func TestEvaluator_EvaluateDoesNotAllocate(t *testing.T) {
+	e := deuces.NewEvaluator()
+	hand := []deuces.Card{mustNewCard("As"), mustNewCard("Kd")}
+	board := []deuces.Card{mustNewCard("Qs"), mustNewCard("Jc"), mustNewCard("7h"), mustNewCard("3h"), mustNewCard("2d")}
+
+	for n := 3; n <= 5; n++ {
+		allocs := testing.AllocsPerRun(100, func() {
+			e.Evaluate(hand, board[:n])
+		})
+		if allocs != 0 {
+			t.Errorf("Evaluate with %d cards allocated %.0f times, want 0", 2+n, allocs)
+		}
+	}
+}
+
+func TestEvaluator_EvaluateChecked(t *testing.T) {
+	e := deuces.NewEvaluator()
+	hand := []deuces.Card{mustNewCard("Qs"), mustNewCard("Th")}
+	board := []deuces.Card{mustNewCard("2h"), mustNewCard("2s"), mustNewCard("Jc")}
+
+	rank, err := e.EvaluateChecked(hand, board)
+	if err != nil {
+		t.Fatalf("EvaluateChecked() error = %v", err)
+	}
+	if rank != 6066 {
+		t.Errorf("EvaluateChecked() = %d, want 6066", rank)
+	}
+}
+
+func TestEvaluator_EvaluateCheckedErrors(t *testing.T) {
+	e := deuces.NewEvaluator()
+	hand := []deuces.Card{mustNewCard("Qs"), mustNewCard("Th")}
+	board := []deuces.Card{mustNewCard("2h"), mustNewCard("2s"), mustNewCard("Jc")}
+
+	t.Run("Too few cards", func(t *testing.T) {
+		_, err := e.EvaluateChecked(hand, board[:2])
+		var countErr *deuces.CardCountError
+		if !errors.As(err, &countErr) || countErr.Count != 4 {
+			t.Errorf("EvaluateChecked() error = %v, want CardCountError with count 4", err)
+		}
+	})
+
+	t.Run("Too many cards", func(t *testing.T) {
+		_, err := e.EvaluateChecked(hand, append(board, mustNewCard("3c"), mustNewCard("4c"), mustNewCard("5c")))
+		var countErr *deuces.CardCountError
+		if !errors.As(err, &countErr) || countErr.Count != 8 {
+			t.Errorf("EvaluateChecked() error = %v, want CardCountError with count 8", err)
+		}
+	})
+
+	t.Run("Duplicate card", func(t *testing.T) {
+		_, err := e.EvaluateChecked(hand, []deuces.Card{mustNewCard("2h"), mustNewCard("Qs"), mustNewCard("Jc")})
+		var dupErr *deuces.DuplicateCardError
+		if !errors.As(err, &dupErr) || dupErr.Card != mustNewCard("Qs") {
+			t.Errorf("EvaluateChecked() error = %v, want DuplicateCardError for Qs", err)
+		}
+	})
+
+	t.Run("Malformed card", func(t *testing.T) {
+		_, err := e.EvaluateChecked(hand, []deuces.Card{mustNewCard("2h"), deuces.Card(7), mustNewCard("Jc")})
+		var invalidErr *deuces.InvalidCardError
+		if !errors.As(err, &invalidErr) || invalidErr.Card != 7 {
+			t.Errorf("EvaluateChecked() error = %v, want InvalidCardError for 7", err)
+		}
+	})
+}
@@ -0,0 +1,173 @@
+package deuces_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestEvaluateEightLow(t *testing.T) {
+	evaluator := deuces.NewEvaluator()
+	testCases := []struct {
+		name  string
+		cards string
+		want  int
+	}{
+		{"Wheel", "5h 4d 3c 2s Ah", 1},
+		{"Straight flush still a wheel", "As 2s 3s 4s 5s", 1},
+		{"Pairs ignored", "Ad Ac 2h 3s 4d 5c Kd", 1},
+		{"Worst low", "8c 7d 6h 5s 4c", deuces.MaxEightLow},
+		{"Nine does not qualify", "9c 7d 6h 5s 4c", deuces.NoLow},
+		{"Four low ranks", "Ac 2d 3h 4s 4c Kd Qh", deuces.NoLow},
+		{"Best five of seven", "8c 7d 6h 5s 4c 3d 2h", 6}, // 6-5-4-3-2
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := evaluator.EvaluateEightLow(mustNewCards(tc.cards), nil); got != tc.want {
+				t.Errorf("EvaluateEightLow() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+
+	// The highest card decides first, then the next ones down
+	better := evaluator.EvaluateEightLow(mustNewCards("6c 4d 3h 2s Ac"), nil)
+	worse := evaluator.EvaluateEightLow(mustNewCards("6c 5d 4h 3s 2c"), nil)
+	if better >= worse {
+		t.Errorf("6-4-3-2-A ranked %d, not better than 6-5-4-3-2 at %d", better, worse)
+	}
+}
+
+func TestEvaluateOmahaEightLow(t *testing.T) {
+	evaluator := deuces.NewEvaluator()
+	testCases := []struct {
+		name        string
+		hand, board string
+		want        int
+	}{
+		{"Nut low", "Ac 2d Kh Kd", "3c 4d 5h 9s Tc", 1},
+		{"One low hole card", "Ac Kd Qh Jd", "2c 3d 4h 5s 8c", deuces.NoLow},
+		{"Two low board cards", "Ac 2d 3h 4d", "5c 6d Kh Qs Jc", deuces.NoLow},
+		{"Counterfeited deuce", "Ac 2d 9h 9d", "2c 5d 7h Ks Qc", deuces.NoLow},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := evaluator.EvaluateOmahaEightLow(mustNewCards(tc.hand), mustNewCards(tc.board)); got != tc.want {
+				t.Errorf("EvaluateOmahaEightLow() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+
+	rng := rand.New(rand.NewSource(2))
+	for i := 0; i < 2000; i++ {
+		deck := deuces.NewDeckWithRNG(rng)
+		hand, board := deck.Draw(4+i%3), deck.Draw(5)
+		want := deuces.NoLow
+		for a := 0; a < len(hand); a++ {
+			for b := a + 1; b < len(hand); b++ {
+				for x := 0; x < len(board); x++ {
+					for y := x + 1; y < len(board); y++ {
+						for z := y + 1; z < len(board); z++ {
+							low := evaluator.EvaluateEightLow([]deuces.Card{hand[a], hand[b]}, []deuces.Card{board[x], board[y], board[z]})
+							want = min(want, low)
+						}
+					}
+				}
+			}
+		}
+		if got := evaluator.EvaluateOmahaEightLow(hand, board); got != want {
+			t.Fatalf("EvaluateOmahaEightLow(%v, %v) = %d, want %d", hand, board, got, want)
+		}
+	}
+}
+
+func TestOmahaHiLoShowdown(t *testing.T) {
+	evaluator := deuces.NewEvaluator()
+	testCases := []struct {
+		name      string
+		hands     []string
+		board     string
+		high, low []int
+		shares    []float64
+		scooper   int
+	}{
+		{
+			"Wheel scoops", []string{"Ac 2d Kh Kd", "Qs Qh Jc Td"}, "3c 4d 5h Ks 9c",
+			[]int{0}, []int{0}, []float64{1, 0}, 0,
+		},
+		{
+			"Quartered", []string{"Ac 2d Kh Kd", "As 2s Qh Jh"}, "3c 4d 8h Ks Kc",
+			[]int{0}, []int{0, 1}, []float64{0.75, 0.25}, -1,
+		},
+		{
+			"No low", []string{"Ac 2d Kh Kd", "As 2s Qh Jh"}, "9c Td Jc Ks Qc",
+			[]int{0, 1}, nil, []float64{0.5, 0.5}, -1,
+		},
+		{
+			"Split between high and low", []string{"Ac 2d 9h 9d", "Kh Kd Qs Jc", "7c 6c Jd Th"}, "3c 4d 8h Ks 2h",
+			[]int{1}, []int{2}, []float64{0, 0.5, 0.5}, -1, // 7-6-4-3-2 beats 8-4-3-2-A
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hands := make([][]deuces.Card, len(tc.hands))
+			for i, hand := range tc.hands {
+				hands[i] = mustNewCards(hand)
+			}
+			showdown, err := evaluator.OmahaHiLoShowdown(hands, mustNewCards(tc.board))
+			if err != nil {
+				t.Fatalf("OmahaHiLoShowdown() error = %v", err)
+			}
+			if !reflect.DeepEqual(showdown.HighWinners, tc.high) || !reflect.DeepEqual(showdown.LowWinners, tc.low) {
+				t.Errorf("winners = %v high and %v low, want %v and %v", showdown.HighWinners, showdown.LowWinners, tc.high, tc.low)
+			}
+			if !reflect.DeepEqual(showdown.Shares, tc.shares) || showdown.Scooper != tc.scooper {
+				t.Errorf("shares = %v, scooper %d, want %v and %d", showdown.Shares, showdown.Scooper, tc.shares, tc.scooper)
+			}
+		})
+	}
+
+	if _, err := evaluator.OmahaHiLoShowdown([][]deuces.Card{mustNewCards("Ac 2d Kh Kd"), mustNewCards("As 2s Qh Jh")}, mustNewCards("3c 4d 8h Ks")); err == nil {
+		t.Error("four card board: expected error")
+	}
+}
+
+func TestEstimateEquity_OmahaHiLo(t *testing.T) {
+	hands := [][]deuces.Card{mustNewCards("Ac 2d Kh Kd"), mustNewCards("As 3s Qh Qc"), mustNewCards("Jd Td 9c 8c")}
+	board := mustNewCards("4c 5d Qs 7h")
+
+	// Settle every river by hand
+	evaluator := deuces.NewEvaluator()
+	deck := deuces.NewDeck()
+	for _, hand := range hands {
+		deck.Remove(hand...)
+	}
+	deck.Remove(board...)
+	want := make([]float64, len(hands))
+	for _, river := range deck.Cards {
+		showdown, err := evaluator.OmahaHiLoShowdown(hands, append(append([]deuces.Card{}, board...), river))
+		if err != nil {
+			t.Fatalf("OmahaHiLoShowdown() error = %v", err)
+		}
+		for p, share := range showdown.Shares {
+			want[p] += share / float64(len(deck.Cards))
+		}
+	}
+
+	result, err := deuces.EstimateEquityWithOptions(context.Background(), hands, board, nil, 20000, deuces.SimulationOptions{Seed: 6, Game: deuces.OmahaHiLo})
+	if err != nil {
+		t.Fatalf("EstimateEquityWithOptions() error = %v", err)
+	}
+	for p := range hands {
+		if got := result.Players[p].Equity; math.Abs(got-want[p]) > 0.01 {
+			t.Errorf("player %d equity = %.4f, want %.4f", p+1, got, want[p])
+		}
+	}
+
+	if _, err := deuces.EstimateWinProbabilityWithOptions(context.Background(), hands[0], board, nil, 2, deuces.MinIterations, deuces.SimulationOptions{Game: deuces.OmahaHiLo}); err == nil {
+		t.Error("EstimateWinProbabilityWithOptions() in Omaha Hi-Lo: expected error")
+	}
+}
@@ -0,0 +1,129 @@
+package deuces_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestEnumerateEquityHistogram_RandomOpponent(t *testing.T) {
+	hand, board := mustNewCards("Ad Qc"), mustNewCards("3h 4c Jh")
+
+	histogram, err := deuces.EnumerateEquityHistogram(hand, board, nil, nil, 50)
+	if err != nil {
+		t.Fatalf("EnumerateEquityHistogram() error = %v", err)
+	}
+	if !histogram.Exact || histogram.TotalIterations != 1081 || len(histogram.Buckets) != 50 {
+		t.Errorf("got Exact %v from %d runouts in %d buckets, want exact from 1081 in 50",
+			histogram.Exact, histogram.TotalIterations, len(histogram.Buckets))
+	}
+	sum := 0.0
+	for _, share := range histogram.Buckets {
+		sum += share
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("buckets sum to %f, want 1", sum)
+	}
+
+	// Against a random hand every runout is equally likely, so the mean is
+	// the hand's equity heads-up.
+	exact, err := deuces.EnumerateWinProbability(hand, board, nil, 1)
+	if err != nil {
+		t.Fatalf("EnumerateWinProbability() error = %v", err)
+	}
+	if math.Abs(histogram.Mean-exact.Equity) > 1e-9 {
+		t.Errorf("Mean = %f, want %f", histogram.Mean, exact.Equity)
+	}
+}
+
+func TestEnumerateEquityHistogram_Range(t *testing.T) {
+	board := mustNewCards("Ts 9s 4d 2c")
+	villain := mustParseRange("TT-88:0.5,KTs+,QJs")
+
+	histogram, err := deuces.EnumerateEquityHistogram(mustNewCards("Kd Kc"), board, nil, villain, 10)
+	if err != nil {
+		t.Fatalf("EnumerateEquityHistogram() error = %v", err)
+	}
+	exact, err := deuces.EnumerateRangeEquity(mustParseRange("KdKc"), []*deuces.Range{villain}, board, nil)
+	if err != nil {
+		t.Fatalf("EnumerateRangeEquity() error = %v", err)
+	}
+	if math.Abs(histogram.Mean-exact.Players[0].Equity) > 1e-9 {
+		t.Errorf("Mean = %f, want the range equity %f", histogram.Mean, exact.Players[0].Equity)
+	}
+	// The kings are behind the sets of tens and nines on every river but a
+	// king, so no runout leaves them above 90%
+	if histogram.Buckets[9] != 0 {
+		t.Errorf("top bucket = %f, want 0", histogram.Buckets[9])
+	}
+}
+
+func TestEnumerateEquityHistogram_River(t *testing.T) {
+	// On the river there is a single runout, in the bucket of its equity:
+	// the nut straight ties AK and beats queens.
+	histogram, err := deuces.EnumerateEquityHistogram(mustNewCards("As Kd"), mustNewCards("Qh Jc Ts 4d 2c"), nil, mustParseRange("AK,QQ"), 4)
+	if err != nil {
+		t.Fatalf("EnumerateEquityHistogram() error = %v", err)
+	}
+	if histogram.TotalIterations != 1 || histogram.Buckets[2] != 1 {
+		t.Errorf("got %v from %d runouts, want everything in the third bucket", histogram.Buckets, histogram.TotalIterations)
+	}
+	// 9 unblocked AK combos tie and 3 queens lose
+	if want := (4.5 + 3) / 12; math.Abs(histogram.Mean-want) > 1e-9 {
+		t.Errorf("Mean = %f, want %f", histogram.Mean, want)
+	}
+}
+
+func TestEstimateEquityHistogram_MatchesEnumeration(t *testing.T) {
+	hand, board := mustNewCards("8h 7h"), mustNewCards("9h Tc 2h")
+	dead := mustNewCards("Ah")
+
+	exact, err := deuces.EnumerateEquityHistogram(hand, board, dead, nil, 10)
+	if err != nil {
+		t.Fatalf("EnumerateEquityHistogram() error = %v", err)
+	}
+	estimate, err := deuces.EstimateEquityHistogram(context.Background(), hand, board, dead, nil, 10, 5000, deuces.SimulationOptions{Seed: 7})
+	if err != nil {
+		t.Fatalf("EstimateEquityHistogram() error = %v", err)
+	}
+	if estimate.Exact || estimate.TotalIterations != 5000 {
+		t.Errorf("got Exact %v from %d iterations, want sampled from 5000", estimate.Exact, estimate.TotalIterations)
+	}
+	if math.Abs(estimate.Mean-exact.Mean) > 0.01 {
+		t.Errorf("Mean: estimate %.4f, exact %.4f", estimate.Mean, exact.Mean)
+	}
+	for i := range exact.Buckets {
+		if math.Abs(estimate.Buckets[i]-exact.Buckets[i]) > 0.03 {
+			t.Errorf("bucket %d: estimate %.4f, exact %.4f", i, estimate.Buckets[i], exact.Buckets[i])
+		}
+	}
+}
+
+func TestEquityHistogram_InputValidation(t *testing.T) {
+	hand := mustNewCards("As Ah")
+	testCases := []struct {
+		name     string
+		board    []deuces.Card
+		dead     []deuces.Card
+		opponent *deuces.Range
+		buckets  int
+	}{
+		{"No buckets", nil, nil, nil, 0},
+		{"Board too large", mustNewCards("2c 3c 4c 5c 6c 7c"), nil, nil, 10},
+		{"Dead card in hand", nil, mustNewCards("As"), nil, 10},
+		{"Empty range", nil, nil, deuces.NewRange(), 10},
+		{"Range blocked", mustNewCards("Kc 2d 3d"), nil, mustParseRange("AsKs,AhKc"), 10},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := deuces.EnumerateEquityHistogram(hand, tc.board, tc.dead, tc.opponent, tc.buckets); err == nil {
+				t.Error("EnumerateEquityHistogram() expected error")
+			}
+			if _, err := deuces.EstimateEquityHistogram(context.Background(), hand, tc.board, tc.dead, tc.opponent, tc.buckets, deuces.MinIterations, deuces.SimulationOptions{}); err == nil {
+				t.Error("EstimateEquityHistogram() expected error")
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package deuces_test
 
 import (
 	"github.com/gregory-chatelier/go-deuces"
+	"math/rand"
 	"testing"
 )
 
@@ -18,3 +19,87 @@ func TestLookupTable_UnsuitedLookupSize(t *testing.T) {
 		t.Errorf("UnsuitedLookup size = %d, want 6175", len(lt.UnsuitedLookup))
 	}
 }
+
+// mapEvaluateFive ranks five cards through the prime product maps, the way
+// the evaluator did before it switched to flat arrays.
+func mapEvaluateFive(lt *deuces.LookupTable, cards []deuces.Card) int {
+	product := 1
+	for _, card := range cards {
+		product *= card.GetPrime()
+	}
+	if (cards[0]&cards[1]&cards[2]&cards[3]&cards[4])&0xF000 != 0 {
+		if value, ok := lt.FlushLookup[product]; ok {
+			return value
+		}
+	}
+	return lt.UnsuitedLookup[product]
+}
+
+func TestLookupTable_MatchesMapsForAllFiveCardHands(t *testing.T) {
+	lt := deuces.NewLookupTable()
+	e := deuces.NewEvaluator()
+	deck := deuces.GetFullDeck()
+
+	hand := make([]deuces.Card, 5)
+	seen := make(map[int]bool)
+	for a := 0; a < 52; a++ {
+		for b := a + 1; b < 52; b++ {
+			for c := b + 1; c < 52; c++ {
+				for d := c + 1; d < 52; d++ {
+					for f := d + 1; f < 52; f++ {
+						hand[0], hand[1], hand[2], hand[3], hand[4] = deck[a], deck[b], deck[c], deck[d], deck[f]
+						want := mapEvaluateFive(lt, hand)
+						if got := e.Evaluate(hand, nil); got != want {
+							t.Fatalf("Evaluate(%v) = %d, want %d", hand, got, want)
+						}
+						seen[want] = true
+					}
+				}
+			}
+		}
+	}
+	if len(seen) != deuces.MaxHighCard {
+		t.Errorf("distinct ranks = %d, want %d", len(seen), deuces.MaxHighCard)
+	}
+}
+
+// randomHands deals n hands of size cards from a fixed seed, sharing one
+// backing array so benchmarks measure evaluation rather than cache misses.
+func randomHands(n, size int) [][]deuces.Card {
+	rng := rand.New(rand.NewSource(42))
+	cards := make([]deuces.Card, 0, n*size)
+	hands := make([][]deuces.Card, n)
+	for i := range hands {
+		cards = append(cards, deuces.NewDeckWithRNG(rng).Draw(size)...)
+		hands[i] = cards[i*size : (i+1)*size : (i+1)*size]
+	}
+	return hands
+}
+
+func BenchmarkLookup_FiveCardsMaps(b *testing.B) {
+	lt := deuces.NewLookupTable()
+	hands := randomHands(4096, 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mapEvaluateFive(lt, hands[i&4095])
+	}
+}
+
+func BenchmarkEvaluator_FiveCards(b *testing.B) {
+	e := deuces.NewEvaluator()
+	hands := randomHands(4096, 5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e.Evaluate(hands[i&4095], nil)
+	}
+}
+
+func BenchmarkEvaluator_SevenCards(b *testing.B) {
+	e := deuces.NewEvaluator()
+	hands := randomHands(4096, 7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hand := hands[i&4095]
+		e.Evaluate(hand[:2], hand[2:])
+	}
+}
@@ -0,0 +1,136 @@
+package deuces_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestAceToFiveEvaluator_Orderings(t *testing.T) {
+	evaluator := deuces.NewAceToFiveEvaluator()
+	// Best first; straights and flushes do not count and aces are low
+	hands := []string{
+		"5c 4d 3h 2s Ac",
+		"6c 4d 3h 2s Ac",
+		"6c 5d 4h 3s 2c",
+		"7c 5d 4h 3s 2c",
+		"8c 7d 6h 5s 4c",
+		"Kc Qd Jh Ts 9c",
+		"Ac Ad 2h 3s 4c",
+		"2c 2d Ah 3s 4c",
+		"Kc Kd Qh Qs Jc",
+		"Ac Ad As 2h 3c",
+		"Kc Kd Ks Qh Qc",
+		"Ac Ad As Ah 2c",
+		"Kc Kd Ks Kh Qc",
+	}
+	assertLowballOrder(t, evaluator, hands)
+
+	for _, wheel := range []string{"5c 4d 3h 2s Ac", "5s 4s 3s 2s As"} {
+		if got := evaluator.Evaluate(mustNewCards(wheel), nil); got != 1 {
+			t.Errorf("%s = %d, want 1", wheel, got)
+		}
+	}
+	if got := evaluator.Evaluate(mustNewCards("Kc Kd Ks Kh Qc"), nil); got != deuces.MaxAceToFive {
+		t.Errorf("kings full of queens' quads = %d, want %d", got, deuces.MaxAceToFive)
+	}
+	if got := evaluator.ClassToString(evaluator.GetRankClass(evaluator.Evaluate(mustNewCards("6c 5d 4h 3s 2c"), nil))); got != "High Card" {
+		t.Errorf("6-5-4-3-2 class = %q, want High Card", got)
+	}
+
+	// Razz: the best low of seven cards
+	if got := evaluator.Evaluate(mustNewCards("Kc Kd 7h"), mustNewCards("Ac 4d 2h 3s")); got != evaluator.Evaluate(mustNewCards("7h 4d 3s 2h Ac"), nil) {
+		t.Errorf("seven card low = %d, want 7-4-3-2-A", got)
+	}
+}
+
+func TestDeuceToSevenEvaluator_Orderings(t *testing.T) {
+	evaluator := deuces.NewDeuceToSevenEvaluator()
+	// Best first; aces are high, and straights and flushes count
+	hands := []string{
+		"7c 5d 4h 3s 2c",
+		"7c 6d 4h 3s 2c",
+		"7c 6d 5h 3s 2c",
+		"7c 6d 5h 4s 2c",
+		"8c 5d 4h 3s 2c",
+		"Kc Qd Jh Ts 8c",
+		"Ac 5d 4h 3s 2c",
+		"Ac Kd Qh Js 9c",
+		"2c 2d 3h 4s 5c",
+		"Ac Ad Kh Qs Jc",
+		"3c 3d 2h 2s 4c",
+		"2c 2d 2h 3s 4c",
+		"6c 5d 4h 3s 2c",
+		"Ac Kd Qh Js Tc",
+		"7s 5s 4s 3s 2s",
+		"As Ks Qs Js 9s",
+		"2c 2d 2h 3s 3c",
+		"2c 2d 2h 2s 3c",
+		"6s 5s 4s 3s 2s",
+		"As Ks Qs Js Ts",
+	}
+	assertLowballOrder(t, evaluator, hands)
+
+	for hand, want := range map[string]int{"7c 5d 4h 3s 2c": 1, "As Ks Qs Js Ts": deuces.MaxDeuceToSeven} {
+		if got := evaluator.Evaluate(mustNewCards(hand), nil); got != want {
+			t.Errorf("%s = %d, want %d", hand, got, want)
+		}
+	}
+	wheel := evaluator.Evaluate(mustNewCards("Ac 5d 4h 3s 2c"), nil)
+	if got := evaluator.ClassToString(evaluator.GetRankClass(wheel)); got != "High Card" {
+		t.Errorf("A-5-4-3-2 class = %q, want High Card", got)
+	}
+}
+
+func TestDeuceToSevenEvaluator_ReversesHighHands(t *testing.T) {
+	high := deuces.NewEvaluator()
+	low := deuces.NewDeuceToSevenEvaluator()
+	isWheel := func(cards []deuces.Card) bool {
+		ranks := 0
+		for _, c := range cards {
+			ranks |= 1 << c.GetRankInt()
+		}
+		return ranks == 0b1000000001111
+	}
+
+	rng := rand.New(rand.NewSource(4))
+	for i := 0; i < 20000; i++ {
+		deck := deuces.NewDeckWithRNG(rng)
+		a, b := deck.Draw(5), deck.Draw(5)
+		if isWheel(a) || isWheel(b) {
+			continue
+		}
+		highA, highB := high.Evaluate(a, nil), high.Evaluate(b, nil)
+		lowA, lowB := low.Evaluate(a, nil), low.Evaluate(b, nil)
+		if (highA < highB) != (lowA > lowB) || (highA == highB) != (lowA == lowB) {
+			t.Fatalf("%v and %v: high ranks %d and %d, deuce-to-seven ranks %d and %d", a, b, highA, highB, lowA, lowB)
+		}
+	}
+}
+
+func TestLowballEvaluator_EvaluateChecked(t *testing.T) {
+	evaluator := deuces.NewAceToFiveEvaluator()
+	if _, err := evaluator.EvaluateChecked(mustNewCards("Ac 2d 3h 4s"), nil); err == nil {
+		t.Error("four cards: expected error")
+	}
+	if _, err := evaluator.EvaluateChecked(mustNewCards("Ac 2d 3h 4s"), mustNewCards("Ac")); err == nil {
+		t.Error("repeated card: expected error")
+	}
+	if rank, err := evaluator.EvaluateChecked(mustNewCards("Ac 2d 3h 4s 5c"), nil); err != nil || rank != 1 {
+		t.Errorf("EvaluateChecked() = %d, %v, want 1", rank, err)
+	}
+}
+
+// assertLowballOrder checks that hands are ranked strictly from best to worst.
+func assertLowballOrder(t *testing.T, evaluator *deuces.LowballEvaluator, hands []string) {
+	t.Helper()
+	previous := 0
+	for _, hand := range hands {
+		rank := evaluator.Evaluate(mustNewCards(hand), nil)
+		if rank <= previous {
+			t.Errorf("%s ranked %d, not worse than the hand before at %d", hand, rank, previous)
+		}
+		previous = rank
+	}
+}
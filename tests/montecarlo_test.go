@@ -1,8 +1,14 @@
 package deuces_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"math/rand"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/gregory-chatelier/go-deuces"
 )
@@ -79,7 +85,7 @@ func TestEstimateWinProbability_InputValidation(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			_, err := deuces.EstimateWinProbability(tc.hand, tc.board, tc.numOpponents, tc.iterations)
+			_, err := deuces.EstimateWinProbability(tc.hand, tc.board, nil, tc.numOpponents, tc.iterations)
 			if err == nil {
 				t.Fatalf("Expected error, but got nil")
 			}
@@ -90,11 +96,27 @@ func TestEstimateWinProbability_InputValidation(t *testing.T) {
 	}
 }
 
+func TestEstimateWinProbability_InvalidCards(t *testing.T) {
+	hand := []deuces.Card{mustNewCard("As"), mustNewCard("Ks")}
+
+	_, err := deuces.EstimateWinProbability(hand, []deuces.Card{mustNewCard("Qs"), mustNewCard("As"), mustNewCard("Ts")}, nil, 1, deuces.MinIterations)
+	var dupErr *deuces.DuplicateCardError
+	if !errors.As(err, &dupErr) {
+		t.Errorf("Expected DuplicateCardError, got %v", err)
+	}
+
+	_, err = deuces.EstimateWinProbability([]deuces.Card{mustNewCard("As"), 0}, nil, nil, 1, deuces.MinIterations)
+	var invalidErr *deuces.InvalidCardError
+	if !errors.As(err, &invalidErr) {
+		t.Errorf("Expected InvalidCardError, got %v", err)
+	}
+}
+
 func TestEstimateWinProbability_Scenarios(t *testing.T) {
 	t.Run("RoyalFlushVsZeroOpponents", func(t *testing.T) {
 		hand := []deuces.Card{mustNewCard("As"), mustNewCard("Ks")}
 		board := []deuces.Card{mustNewCard("Qs"), mustNewCard("Js"), mustNewCard("Ts")}
-		result, err := deuces.EstimateWinProbability(hand, board, 0, deuces.MinIterations)
+		result, err := deuces.EstimateWinProbability(hand, board, nil, 0, deuces.MinIterations)
 		if err != nil {
 			t.Fatalf("Did not expect error, but got: %v", err)
 		}
@@ -109,7 +131,7 @@ func TestEstimateWinProbability_Scenarios(t *testing.T) {
 	t.Run("RoyalFlushOnBoardVsOneOpponent", func(t *testing.T) {
 		hand := []deuces.Card{mustNewCard("2c"), mustNewCard("3d")}
 		board := []deuces.Card{mustNewCard("As"), mustNewCard("Ks"), mustNewCard("Qs"), mustNewCard("Js"), mustNewCard("Ts")}
-		result, err := deuces.EstimateWinProbability(hand, board, 1, deuces.MinIterations)
+		result, err := deuces.EstimateWinProbability(hand, board, nil, 1, deuces.MinIterations)
 		if err != nil {
 			t.Fatalf("Did not expect error, but got: %v", err)
 		}
@@ -125,7 +147,7 @@ func TestEstimateWinProbability_Scenarios(t *testing.T) {
 		const iterations = 100000
 		hand := []deuces.Card{mustNewCard("As"), mustNewCard("Ac")}
 		board := []deuces.Card{}
-		result, err := deuces.EstimateWinProbability(hand, board, 1, iterations)
+		result, err := deuces.EstimateWinProbability(hand, board, nil, 1, iterations)
 		if err != nil {
 			t.Fatalf("Did not expect error, but got: %v", err)
 		}
@@ -140,7 +162,7 @@ func TestEstimateWinProbability_Scenarios(t *testing.T) {
 		// User has a flush and a straight flush draw
 		hand := []deuces.Card{mustNewCard("8s"), mustNewCard("7s")}
 		board := []deuces.Card{mustNewCard("6s"), mustNewCard("5s"), mustNewCard("As")}
-		result, err := deuces.EstimateWinProbability(hand, board, 2, iterations)
+		result, err := deuces.EstimateWinProbability(hand, board, nil, 2, iterations)
 		if err != nil {
 			t.Fatalf("Did not expect error, but got: %v", err)
 		}
@@ -153,7 +175,7 @@ func TestEstimateWinProbability_Scenarios(t *testing.T) {
 		const iterations = 100000
 		hand := []deuces.Card{mustNewCard("As"), mustNewCard("Kc")}
 		board := []deuces.Card{}
-		result, err := deuces.EstimateWinProbability(hand, board, 1, iterations)
+		result, err := deuces.EstimateWinProbability(hand, board, nil, 1, iterations)
 		if err != nil {
 			t.Fatalf("Did not expect error, but got: %v", err)
 		}
@@ -167,13 +189,13 @@ func TestEstimateWinProbability_Scenarios(t *testing.T) {
 		const iterations = 100000
 		hand := []deuces.Card{mustNewCard("As"), mustNewCard("Ac")}
 		board := []deuces.Card{}
-		result, err := deuces.EstimateWinProbability(hand, board, 5, iterations)
+		result, err := deuces.EstimateWinProbability(hand, board, nil, 5, iterations)
 		if err != nil {
 			t.Fatalf("Did not expect error, but got: %v", err)
 		}
-		// Win probability for AA vs 5 random hands is ~50%
-		if result.WinProbability < 0.25 || result.WinProbability > 0.35 {
-			t.Errorf("Pocket Aces vs 5: Expected win probability around 30%%, got %.2f%%", result.WinProbability*100)
+		// Win probability for AA vs 5 random hands is ~49%
+		if result.WinProbability < 0.45 || result.WinProbability > 0.53 {
+			t.Errorf("Pocket Aces vs 5: Expected win probability around 49%%, got %.2f%%", result.WinProbability*100)
 		}
 	})
 
@@ -181,7 +203,7 @@ func TestEstimateWinProbability_Scenarios(t *testing.T) {
 		const iterations = 100000
 		hand := []deuces.Card{mustNewCard("7h"), mustNewCard("2d")}
 		board := []deuces.Card{}
-		result, err := deuces.EstimateWinProbability(hand, board, 8, iterations)
+		result, err := deuces.EstimateWinProbability(hand, board, nil, 8, iterations)
 		if err != nil {
 			t.Fatalf("Did not expect error, but got: %v", err)
 		}
@@ -191,3 +213,202 @@ func TestEstimateWinProbability_Scenarios(t *testing.T) {
 		}
 	})
 }
+
+func TestEstimateWinProbabilityContext_Cancelled(t *testing.T) {
+	hand := mustNewCards("As Ks")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := deuces.EstimateWinProbabilityContext(ctx, hand, nil, nil, 1, 1000000, nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if result == nil || result.TotalIterations != 0 {
+		t.Errorf("result = %+v, want no iterations", result)
+	}
+}
+
+func TestEstimateWinProbabilityContext_Deadline(t *testing.T) {
+	hand := mustNewCards("As Ks")
+	iterations := 1000000000
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result, err := deuces.EstimateWinProbabilityContext(ctx, hand, nil, nil, 3, iterations, nil)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("took %v to stop after the deadline", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want context.DeadlineExceeded", err)
+	}
+	if result.TotalIterations == 0 || result.TotalIterations >= iterations {
+		t.Fatalf("TotalIterations = %d, want a partial run", result.TotalIterations)
+	}
+	if sum := result.WinProbability + result.TieProbability + result.LossProbability; sum < 0.999 || sum > 1.001 {
+		t.Errorf("partial probabilities sum to %f, want 1", sum)
+	}
+}
+
+func TestEstimateWinProbabilityContext_Progress(t *testing.T) {
+	hand := mustNewCards("As Ks")
+
+	var mu sync.Mutex
+	var snapshots []deuces.HandResult
+	progress := func(partial deuces.HandResult) {
+		mu.Lock()
+		defer mu.Unlock()
+		snapshots = append(snapshots, partial)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*deuces.ProgressInterval+50*time.Millisecond)
+	defer cancel()
+	if _, err := deuces.EstimateWinProbabilityContext(ctx, hand, nil, nil, 3, 1000000000, progress); err == nil {
+		t.Fatal("expected the deadline to stop the simulation")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(snapshots) < 2 {
+		t.Fatalf("got %d progress snapshots, want at least 2", len(snapshots))
+	}
+	for i := 1; i < len(snapshots); i++ {
+		if snapshots[i].TotalIterations <= snapshots[i-1].TotalIterations {
+			t.Errorf("snapshot %d has %d iterations, after %d", i, snapshots[i].TotalIterations, snapshots[i-1].TotalIterations)
+		}
+	}
+}
+
+func TestEstimateWinProbabilityWithOptions_Reproducible(t *testing.T) {
+	hand := mustNewCards("As Ks")
+	board := mustNewCards("Qs 8d 2c")
+
+	run := func(opts deuces.SimulationOptions) deuces.HandResult {
+		t.Helper()
+		result, err := deuces.EstimateWinProbabilityWithOptions(context.Background(), hand, board, nil, 2, 25500, opts)
+		if err != nil {
+			t.Fatalf("EstimateWinProbabilityWithOptions() error = %v", err)
+		}
+		return *result
+	}
+
+	first := run(deuces.SimulationOptions{Seed: 42})
+	for _, workers := range []int{1, 3, 16} {
+		if got := run(deuces.SimulationOptions{Seed: 42, Workers: workers}); got != first {
+			t.Errorf("with %d workers got %v, want %v", workers, got, first)
+		}
+	}
+	if got := run(deuces.SimulationOptions{Seed: 43}); got == first {
+		t.Errorf("seeds 42 and 43 both gave %v", got)
+	}
+}
+
+func TestEstimateWinProbabilityWithOptions_NewSource(t *testing.T) {
+	var mu sync.Mutex
+	sources := 0
+	opts := deuces.SimulationOptions{
+		Seed:    7,
+		Workers: 2,
+		NewSource: func(seed int64) rand.Source {
+			mu.Lock()
+			defer mu.Unlock()
+			sources++
+			return rand.NewSource(seed)
+		},
+	}
+	if _, err := deuces.EstimateWinProbabilityWithOptions(context.Background(), mustNewCards("As Ks"), nil, nil, 1, 10000, opts); err != nil {
+		t.Fatalf("EstimateWinProbabilityWithOptions() error = %v", err)
+	}
+	if sources != 2 {
+		t.Errorf("NewSource called %d times, want once per worker", sources)
+	}
+}
+
+func TestEstimateWinProbability_SplitPots(t *testing.T) {
+	// Everyone plays the royal flush on the board, so every pot is split
+	// four ways.
+	result, err := deuces.EstimateWinProbability(mustNewCards("2c 3d"), mustNewCards("As Ks Qs Js Ts"), nil, 3, 5000)
+	if err != nil {
+		t.Fatalf("EstimateWinProbability() error = %v", err)
+	}
+	if result.TieProbability != 1 || result.Equity != 0.25 || result.EquityStdErr != 0 {
+		t.Errorf("got tie %f, equity %f ± %f, want 1 and 0.25 ± 0", result.TieProbability, result.Equity, result.EquityStdErr)
+	}
+	if result.TieSizes[4] != 5000 {
+		t.Errorf("TieSizes = %v, want 5000 four-way ties", result.TieSizes)
+	}
+}
+
+func TestEnumerateWinProbability_Equity(t *testing.T) {
+	// Ace high on a paired board, tied by any ace without a better kicker.
+	result, err := deuces.EnumerateWinProbability(mustNewCards("Ah 3c"), mustNewCards("Ks Kd 7h 4c 2s"), nil, 2)
+	if err != nil {
+		t.Fatalf("EnumerateWinProbability() error = %v", err)
+	}
+	ties := 0
+	shares := float64(0)
+	for k, n := range result.TieSizes {
+		ties += n
+		if k > 0 {
+			shares += float64(n) / float64(k)
+		}
+	}
+	if ties == 0 || result.TieSizes[2] == 0 || result.TieSizes[3] == 0 {
+		t.Fatalf("TieSizes = %v, want two and three way ties", result.TieSizes)
+	}
+	if want := float64(ties) / float64(result.TotalIterations); math.Abs(result.TieProbability-want) > 1e-12 {
+		t.Errorf("TieSizes add up to a tie probability of %f, want %f", want, result.TieProbability)
+	}
+	want := result.WinProbability + shares/float64(result.TotalIterations)
+	if math.Abs(result.Equity-want) > 1e-12 {
+		t.Errorf("Equity = %f, want %f", result.Equity, want)
+	}
+	if result.Equity <= result.WinProbability || result.Equity >= result.WinOrTieProbability {
+		t.Errorf("Equity %f is not between win %f and win or tie %f", result.Equity, result.WinProbability, result.WinOrTieProbability)
+	}
+}
+
+// benchmarkIterations is the number of iterations each simulation benchmark
+// runs per operation.
+const benchmarkIterations = 10000
+
+// BenchmarkEstimateWinProbability runs the simulation on a single worker, to
+// compare with BenchmarkEstimateWinProbability_DeckPerIteration.
+func BenchmarkEstimateWinProbability(b *testing.B) {
+	hand := mustNewCards("As Ks")
+	board := mustNewCards("Qs 8d 2c")
+	opts := deuces.SimulationOptions{Seed: 1, Workers: 1}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := deuces.EstimateWinProbabilityWithOptions(context.Background(), hand, board, nil, 3, benchmarkIterations, opts); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.N*benchmarkIterations)/b.Elapsed().Seconds(), "iterations/s")
+}
+
+// BenchmarkEstimateWinProbability_DeckPerIteration runs the simulation the
+// way it used to, building, shuffling and splicing a whole deck for every
+// iteration.
+func BenchmarkEstimateWinProbability_DeckPerIteration(b *testing.B) {
+	hand := mustNewCards("As Ks")
+	board := mustNewCards("Qs 8d 2c")
+	known := append(append([]deuces.Card{}, hand...), board...)
+	e := deuces.NewEvaluator()
+	rng := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < benchmarkIterations; j++ {
+			deck := deuces.NewDeckWithRNG(rng)
+			deck.Remove(known...)
+			currentBoard := append(append([]deuces.Card{}, board...), deck.Draw(2)...)
+			heroRank := e.Evaluate(hand, currentBoard)
+			for k := 0; k < 3; k++ {
+				if e.Evaluate(deck.Draw(2), currentBoard) < heroRank {
+					break
+				}
+			}
+		}
+	}
+	b.ReportMetric(float64(b.N*benchmarkIterations)/b.Elapsed().Seconds(), "iterations/s")
+}
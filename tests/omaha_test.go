@@ -0,0 +1,149 @@
+package deuces_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestEvaluateOmaha_UsesTwoHoleCards(t *testing.T) {
+	evaluator := deuces.NewEvaluator()
+	testCases := []struct {
+		name       string
+		hand       string
+		board      string
+		best, want string // the two hole cards and three board cards that play
+	}{
+		{"One heart makes no flush", "Ah 2c 3d 4s", "Kh Qh Jh Th 5c", "Ah 4s", "Kh Qh Jh"},
+		{"Quads on board play as trips", "Ac Kc Qd Jd", "9c 9d 9h 9s 2c", "Ac Kc", "9c 9d 9h"},
+		{"Two pair in hand", "As Ad Ks Kd", "Ah 7c 2d", "As Ad", "Ah 7c 2d"},
+		{"Straight from two cards", "8c 9d 2s 2h", "Tc Jh Qd 3s 3c", "8c 9d", "Tc Jh Qd"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := evaluator.EvaluateOmaha(mustNewCards(tc.hand), mustNewCards(tc.board))
+			if want := evaluator.Evaluate(mustNewCards(tc.best), mustNewCards(tc.want)); got != want {
+				t.Errorf("EvaluateOmaha() = %v, want %v", deuces.HandRank(got), deuces.HandRank(want))
+			}
+		})
+	}
+}
+
+func TestEvaluateOmaha_MatchesBruteForce(t *testing.T) {
+	evaluator := deuces.NewEvaluator()
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		holeCards := 4 + i%3
+		boardCards := 3 + i%3
+		deck := deuces.NewDeckWithRNG(rng)
+		hand, board := deck.Draw(holeCards), deck.Draw(boardCards)
+
+		want := deuces.MaxHighCard
+		for a := 0; a < len(hand); a++ {
+			for b := a + 1; b < len(hand); b++ {
+				for x := 0; x < len(board); x++ {
+					for y := x + 1; y < len(board); y++ {
+						for z := y + 1; z < len(board); z++ {
+							rank := evaluator.Evaluate([]deuces.Card{hand[a], hand[b]}, []deuces.Card{board[x], board[y], board[z]})
+							want = min(want, rank)
+						}
+					}
+				}
+			}
+		}
+		if got := evaluator.EvaluateOmaha(hand, board); got != want {
+			t.Fatalf("EvaluateOmaha(%v, %v) = %d, want %d", hand, board, got, want)
+		}
+	}
+}
+
+func TestEvaluateOmaha_CardCounts(t *testing.T) {
+	evaluator := deuces.NewEvaluator()
+	board := mustNewCards("Kh Qh Jh Th 5c")
+	for _, hand := range []string{"Ah 2c 3d", "Ah 2c 3d 4s 5s 6s 7s"} {
+		if got := evaluator.EvaluateOmaha(mustNewCards(hand), board); got != -1 {
+			t.Errorf("EvaluateOmaha(%s) = %d, want -1", hand, got)
+		}
+	}
+	if got := evaluator.EvaluateOmaha(mustNewCards("Ah 2c 3d 4s"), mustNewCards("Kh Qh")); got != -1 {
+		t.Errorf("EvaluateOmaha() with a two card board = %d, want -1", got)
+	}
+}
+
+func TestEstimateEquity_Omaha(t *testing.T) {
+	hands := [][]deuces.Card{mustNewCards("As Ah Ks Kh"), mustNewCards("Jc Tc 9d 8d")}
+	board := mustNewCards("Qc 7d 2s 3h")
+
+	// Enumerate the river by hand
+	evaluator := deuces.NewEvaluator()
+	deck := deuces.NewDeck()
+	deck.Remove(append(append(append([]deuces.Card{}, hands[0]...), hands[1]...), board...)...)
+	wins, ties := 0.0, 0.0
+	for _, river := range deck.Cards {
+		full := append(append([]deuces.Card{}, board...), river)
+		hero, villain := evaluator.EvaluateOmaha(hands[0], full), evaluator.EvaluateOmaha(hands[1], full)
+		if hero < villain {
+			wins++
+		} else if hero == villain {
+			ties++
+		}
+	}
+	want := (wins + ties/2) / float64(len(deck.Cards))
+
+	result, err := deuces.EstimateEquityWithOptions(context.Background(), hands, board, nil, 20000, deuces.SimulationOptions{Seed: 3, Game: deuces.Omaha})
+	if err != nil {
+		t.Fatalf("EstimateEquityWithOptions() error = %v", err)
+	}
+	if math.Abs(result.Players[0].Equity-want) > 0.01 {
+		t.Errorf("equity = %.4f, want %.4f", result.Players[0].Equity, want)
+	}
+}
+
+func TestEstimateWinProbability_Omaha(t *testing.T) {
+	// On the river the nut flush only loses to the full houses and straight
+	// flushes that the paired board allows.
+	hand := mustNewCards("Ah Kh 2c 2d 5s")
+	board := mustNewCards("Qh 8h 3h 8c 4s")
+	opts := deuces.SimulationOptions{Seed: 8, Game: deuces.Omaha}
+
+	result, err := deuces.EstimateWinProbabilityWithOptions(context.Background(), hand, board, nil, 2, 20000, opts)
+	if err != nil {
+		t.Fatalf("EstimateWinProbabilityWithOptions() error = %v", err)
+	}
+	if result.WinProbability < 0.5 || result.LossProbability == 0 {
+		t.Errorf("got %v, want mostly wins and some losses", result)
+	}
+
+	// A single heart makes a royal flush in Hold'em but only ace high in Omaha
+	aceHigh, err := deuces.EstimateWinProbabilityWithOptions(context.Background(), mustNewCards("Ah 2c 3d 4s"), mustNewCards("Kh Qh Jh Th 5c"), nil, 1, 20000, opts)
+	if err != nil {
+		t.Fatalf("EstimateWinProbabilityWithOptions() error = %v", err)
+	}
+	if aceHigh.LossProbability < 0.9 {
+		t.Errorf("ace high lost %.4f of the time, want almost always", aceHigh.LossProbability)
+	}
+}
+
+func TestOmaha_InputValidation(t *testing.T) {
+	ctx := context.Background()
+	omaha := deuces.SimulationOptions{Game: deuces.Omaha}
+
+	if _, err := deuces.EstimateWinProbabilityWithOptions(ctx, mustNewCards("As Ah Ks"), nil, nil, 1, deuces.MinIterations, omaha); err == nil {
+		t.Error("three card Omaha hand: expected error")
+	}
+	if _, err := deuces.EstimateWinProbabilityWithOptions(ctx, mustNewCards("As Ah Ks Kh 2c 2d"), nil, nil, 8, deuces.MinIterations, omaha); err == nil {
+		t.Error("nine six card hands: expected error")
+	}
+	if _, err := deuces.EstimateEquityWithOptions(ctx, [][]deuces.Card{mustNewCards("As Ah Ks Kh"), mustNewCards("Jc Tc 9d 8d 7d")}, nil, nil, deuces.MinIterations, omaha); err == nil {
+		t.Error("hands of different sizes: expected error")
+	}
+	if _, err := deuces.EstimateEquityWithOptions(ctx, [][]deuces.Card{mustNewCards("As Ah"), mustNewCards("Jc Tc")}, nil, nil, deuces.MinIterations, omaha); err == nil {
+		t.Error("Hold'em hands in Omaha: expected error")
+	}
+	if _, err := deuces.EstimateRangeEquityWithOptions(ctx, mustParseRange("AA"), []*deuces.Range{mustParseRange("KK")}, nil, nil, deuces.MinIterations, omaha); err == nil {
+		t.Error("range equity in Omaha: expected error")
+	}
+}
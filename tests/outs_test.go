@@ -0,0 +1,113 @@
+package deuces_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestCalculateOuts_FlushDraw(t *testing.T) {
+	// Behind queens, nine hearts make the nut flush and the six unseen aces
+	// and kings make a better pair. The queen of hearts also gives the
+	// queens a set, and the deuce of hearts two pair.
+	result, err := deuces.CalculateOuts(mustNewCards("Ah Kh"), mustNewCards("9h 5h 2c"), nil, mustNewCards("Qs Qd"))
+	if err != nil {
+		t.Fatalf("CalculateOuts() error = %v", err)
+	}
+	if result.Count() != 15 || result.Unseen != 45 {
+		t.Errorf("got %d outs of %d, want 15 of 45", result.Count(), result.Unseen)
+	}
+	if math.Abs(result.Probability()-15.0/45) > 1e-9 {
+		t.Errorf("Probability() = %f, want %f", result.Probability(), 15.0/45)
+	}
+
+	groups := result.ByClass()
+	if len(groups[deuces.Flush]) != 9 || len(groups[deuces.Pair]) != 6 {
+		t.Errorf("got %d flush and %d pair outs, want 9 and 6", len(groups[deuces.Flush]), len(groups[deuces.Pair]))
+	}
+	for _, out := range groups[deuces.Flush] {
+		if want := out.Card == mustNewCard("Qh") || out.Card == mustNewCard("2h"); out.ImprovesOpponent != want {
+			t.Errorf("%s: ImprovesOpponent = %v, want %v", out.Card, out.ImprovesOpponent, want)
+		}
+	}
+	if got, want := result.String(), "15 outs of 45 (33.33%): Flush 9 (2 improving the opponent), Pair 6"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestCalculateOuts_SetOuts(t *testing.T) {
+	result, err := deuces.CalculateOuts(mustNewCards("7c 7d"), mustNewCards("Ks 8h 2c 3d"), nil, mustNewCards("Ah Kd"))
+	if err != nil {
+		t.Fatalf("CalculateOuts() error = %v", err)
+	}
+	sets := result.ByClass()[deuces.ThreeOfAKind]
+	if result.Count() != 2 || len(sets) != 2 {
+		t.Fatalf("got %v, want the two sevens as set outs", result)
+	}
+	if sets[0].Card != mustNewCard("7h") && sets[0].Card != mustNewCard("7s") {
+		t.Errorf("set out = %s, want a seven", sets[0].Card)
+	}
+}
+
+func TestCalculateOuts_Ahead(t *testing.T) {
+	result, err := deuces.CalculateOuts(mustNewCards("As Ad"), mustNewCards("9h 5h 2c"), nil, mustNewCards("Ks Kd"))
+	if err != nil {
+		t.Fatalf("CalculateOuts() error = %v", err)
+	}
+	if result.Count() != 0 {
+		t.Errorf("got %v, want no outs when already ahead", result)
+	}
+}
+
+func TestCalculateOutsAgainstRange(t *testing.T) {
+	// Against queens and a set of nines, a heart wins against both unless it
+	// pairs the board and fills the set, and an ace or king beats only the
+	// queens.
+	result, err := deuces.CalculateOutsAgainstRange(mustNewCards("Ah Kh"), mustNewCards("9h 5h 2c"), nil, mustParseRange("QQ,99"))
+	if err != nil {
+		t.Fatalf("CalculateOutsAgainstRange() error = %v", err)
+	}
+	if result.Unseen != 47 {
+		t.Errorf("Unseen = %d, want 47", result.Unseen)
+	}
+	shares := make(map[deuces.Card]float64)
+	for _, out := range result.Outs {
+		shares[out.Card] = out.Share
+	}
+	for card, want := range map[string]float64{"3h": 1, "2h": 6.0 / 9, "As": 6.0 / 9, "Qs": 0} {
+		if got := shares[mustNewCard(card)]; math.Abs(got-want) > 1e-9 {
+			t.Errorf("share of %s = %f, want %f", card, got, want)
+		}
+	}
+}
+
+func TestCalculateOuts_InputValidation(t *testing.T) {
+	hand := mustNewCards("Ah Kh")
+	testCases := []struct {
+		name     string
+		board    []deuces.Card
+		dead     []deuces.Card
+		opponent []deuces.Card
+	}{
+		{"Preflop", nil, nil, mustNewCards("Qs Qd")},
+		{"River", mustNewCards("9h 5h 2c 3d 4s"), nil, mustNewCards("Qs Qd")},
+		{"Opponent with one card", mustNewCards("9h 5h 2c"), nil, mustNewCards("Qs")},
+		{"Opponent card on board", mustNewCards("9h 5h 2c"), nil, mustNewCards("Qs 9h")},
+		{"Dead card in hand", mustNewCards("9h 5h 2c"), mustNewCards("Ah"), mustNewCards("Qs Qd")},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := deuces.CalculateOuts(hand, tc.board, tc.dead, tc.opponent); err == nil {
+				t.Error("CalculateOuts() expected error")
+			}
+		})
+	}
+
+	if _, err := deuces.CalculateOutsAgainstRange(hand, mustNewCards("9h 5h 2c"), nil, nil); err == nil {
+		t.Error("nil range: expected error")
+	}
+	if _, err := deuces.CalculateOutsAgainstRange(hand, mustNewCards("9h 5h 2c"), nil, mustParseRange("AhKh")); err == nil {
+		t.Error("blocked range: expected error")
+	}
+}
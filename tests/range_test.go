@@ -0,0 +1,182 @@
+package deuces_test
+
+import (
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func mustParseRange(s string) *deuces.Range {
+	r, err := deuces.ParseRange(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func TestParseRange_Count(t *testing.T) {
+	testCases := []struct {
+		notation string
+		want     int
+	}{
+		{"AKs", 4},
+		{"AKo", 12},
+		{"AK", 16},
+		{"TT", 6},
+		{"TT+", 30},
+		{"22+", 78},
+		{"A9s+", 20},
+		{"KQ+", 16},
+		{"K9o+", 48},
+		{"A2s-A5s", 16},
+		{"A5s-A2s", 16},
+		{"55-22", 24},
+		{"AhKh", 1},
+		{"TT+,AKs,A5s-A2s", 50},
+		{"AK,AKs", 16},
+		{" AA , KK ", 12},
+		{"", 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.notation, func(t *testing.T) {
+			r, err := deuces.ParseRange(tc.notation)
+			if err != nil {
+				t.Fatalf("ParseRange(%q) error = %v", tc.notation, err)
+			}
+			if got := r.Count(); got != tc.want {
+				t.Errorf("Count() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseRange_Weights(t *testing.T) {
+	r := mustParseRange("AK:0.5,AKs")
+	ah, kh, kd := mustNewCard("Ah"), mustNewCard("Kh"), mustNewCard("Kd")
+
+	if w := r.Weight(ah, kh); w != 1 {
+		t.Errorf("Weight(AhKh) = %v, want 1", w)
+	}
+	if w := r.Weight(kd, ah); w != 0.5 {
+		t.Errorf("Weight(KdAh) = %v, want 0.5", w)
+	}
+	if w := r.WeightedCount(); w != 10 {
+		t.Errorf("WeightedCount() = %v, want 10", w)
+	}
+
+	combos := r.Combos()
+	if len(combos) != 16 {
+		t.Fatalf("len(Combos()) = %d, want 16", len(combos))
+	}
+	for _, c := range combos {
+		if c.Cards[0].GetRankInt() != 12 || c.Cards[1].GetRankInt() != 11 {
+			t.Errorf("combo %v is not ace first, then king", c.Cards)
+		}
+	}
+}
+
+func TestParseRange_Invalid(t *testing.T) {
+	for _, notation := range []string{
+		"AKx", "A", "AAs", "1K", "AhAh", "AhKx", "TT-AKs", "AKs-QJs", "A2s-A5o",
+		"AK:0", "AK:1.5", "AK:x",
+	} {
+		if _, err := deuces.ParseRange(notation); err == nil {
+			t.Errorf("ParseRange(%q) expected error", notation)
+		}
+	}
+}
+
+func TestRange_SetOperations(t *testing.T) {
+	a := mustParseRange("TT+,AK:0.5")
+	b := mustParseRange("QQ-88,AK")
+
+	if got := a.Union(b).Count(); got != 6*7+16 {
+		t.Errorf("Union().Count() = %d, want %d", got, 6*7+16)
+	}
+	if got := a.Union(b).WeightedCount(); got != 6*7+16 {
+		t.Errorf("Union().WeightedCount() = %v, want %d", got, 6*7+16)
+	}
+	if got := a.Intersect(b).String(); got != "QQ-TT,AK:0.5" {
+		t.Errorf("Intersect() = %q, want %q", got, "QQ-TT,AK:0.5")
+	}
+	if got := a.Subtract(b).String(); got != "KK+" {
+		t.Errorf("Subtract() = %q, want %q", got, "KK+")
+	}
+}
+
+func TestRange_SetIdenticalCards(t *testing.T) {
+	r := deuces.NewRange()
+	for _, c := range deuces.GetFullDeck() {
+		r.Set(c, c, 1)
+	}
+	if got := r.Count(); got != 0 {
+		t.Errorf("Count() = %d after setting identical cards, want 0", got)
+	}
+
+	r = mustParseRange("22+")
+	for _, c := range deuces.GetFullDeck() {
+		r.Set(c, c, 0)
+	}
+	if got := r.Count(); got != 13*6 {
+		t.Errorf("Count() = %d after clearing identical cards, want %d", got, 13*6)
+	}
+}
+
+func TestRange_InvalidCards(t *testing.T) {
+	r := mustParseRange("AA")
+	ace := mustNewCard("As")
+	r.Set(0, ace, 1)
+	r.Set(ace, 0, 1)
+	if got := r.Count(); got != 6 {
+		t.Errorf("Count() = %d after setting an invalid card, want 6", got)
+	}
+	if got := r.Weight(0, ace); got != 0 {
+		t.Errorf("Weight() with an invalid card = %v, want 0", got)
+	}
+	if got := r.RemoveCards(0, mustNewCard("Ah")).Count(); got != 3 {
+		t.Errorf("RemoveCards().Count() = %d, want 3", got)
+	}
+}
+
+func TestRange_RemoveCards(t *testing.T) {
+	r := mustParseRange("AA,AKs")
+	removed := r.RemoveCards(mustNewCards("As Kh")...)
+
+	if got := removed.Count(); got != 3+2 {
+		t.Errorf("Count() = %d, want 5", got)
+	}
+	if r.Count() != 10 {
+		t.Errorf("RemoveCards modified the original range")
+	}
+}
+
+func TestRange_String(t *testing.T) {
+	testCases := []struct {
+		notation string
+		want     string
+	}{
+		{"TT+,AKs,A5s-A2s:0.5,KhQh", "TT+,AKs,A5s-A2s:0.5,KhQh"},
+		{"22+", "22+"},
+		{"AA", "AA"},
+		{"KK", "KK"},
+		{"A2s+", "A2s+"},
+		{"AKs,AKo", "AK"},
+		{"AQ+", "AQ+"},
+		{"AK", "AK"},
+		{"KQs,KJs,KTs", "KTs+"},
+		{"22-55", "55-22"},
+		{"AK,AKs:0.5", "AKs:0.5,AKo"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.notation, func(t *testing.T) {
+			r := mustParseRange(tc.notation)
+			got := r.String()
+			if got != tc.want {
+				t.Errorf("String() = %q, want %q", got, tc.want)
+			}
+			if back := mustParseRange(got); back.String() != got || back.WeightedCount() != r.WeightedCount() {
+				t.Errorf("ParseRange(%q) does not read back the same range", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package deuces_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestEnumerateRangeEquity_CardRemovalAndWeights(t *testing.T) {
+	board := mustNewCards("2c 3d 7h 8s Jc")
+	hero := mustParseRange("AsAh")
+
+	// Villain's aces other than AdAc are blocked by the hero's hand, leaving
+	// one combo that ties and six kings that lose.
+	result, err := deuces.EnumerateRangeEquity(hero, []*deuces.Range{mustParseRange("AA,KK")}, board, nil)
+	if err != nil {
+		t.Fatalf("EnumerateRangeEquity() error = %v", err)
+	}
+	if !result.Exact || result.TotalIterations != 7 {
+		t.Errorf("got Exact %v from %d showdowns, want exact from 7", result.Exact, result.TotalIterations)
+	}
+	want := deuces.PlayerEquity{WinProbability: 6.0 / 7, TieProbability: 1.0 / 7, Equity: 6.5 / 7}
+	if !equityClose(result.Players[0], want) {
+		t.Errorf("hero = %+v, want %+v", result.Players[0], want)
+	}
+
+	// Halving the kings' weight makes the tie as likely as the three wins.
+	result, err = deuces.EnumerateRangeEquity(hero, []*deuces.Range{mustParseRange("AA,KK:0.5")}, board, nil)
+	if err != nil {
+		t.Fatalf("EnumerateRangeEquity() error = %v", err)
+	}
+	want = deuces.PlayerEquity{WinProbability: 0.75, TieProbability: 0.25, Equity: 0.875}
+	if !equityClose(result.Players[0], want) {
+		t.Errorf("weighted hero = %+v, want %+v", result.Players[0], want)
+	}
+
+	// A dead king removes three of the six kings.
+	result, err = deuces.EnumerateRangeEquity(hero, []*deuces.Range{mustParseRange("AA,KK")}, board, mustNewCards("Kh"))
+	if err != nil {
+		t.Fatalf("EnumerateRangeEquity() error = %v", err)
+	}
+	if result.TotalIterations != 4 {
+		t.Errorf("with a dead king, got %d showdowns, want 4", result.TotalIterations)
+	}
+}
+
+func TestEnumerateRangeEquity_MatchesKnownHands(t *testing.T) {
+	board := mustNewCards("Qs 8s 2d")
+	hands := [][]deuces.Card{mustNewCards("As Ks"), mustNewCards("Qd Qc"), mustNewCards("7h 7d")}
+
+	known, err := deuces.EnumerateEquity(hands, board, nil)
+	if err != nil {
+		t.Fatalf("EnumerateEquity() error = %v", err)
+	}
+	ranges, err := deuces.EnumerateRangeEquity(mustParseRange("AsKs"),
+		[]*deuces.Range{mustParseRange("QdQc"), mustParseRange("7h7d")}, board, nil)
+	if err != nil {
+		t.Fatalf("EnumerateRangeEquity() error = %v", err)
+	}
+	for i := range hands {
+		if !equityClose(known.Players[i], ranges.Players[i]) {
+			t.Errorf("player %d = %+v, want %+v", i+1, ranges.Players[i], known.Players[i])
+		}
+	}
+}
+
+func TestEstimateRangeEquity_MatchesEnumeration(t *testing.T) {
+	board := mustNewCards("Ts 9s 4d 2c")
+	hero := mustParseRange("AQs+,JJ+")
+	villains := []*deuces.Range{mustParseRange("TT-88:0.5,KTs+,QJs")}
+
+	exact, err := deuces.EnumerateRangeEquity(hero, villains, board, nil)
+	if err != nil {
+		t.Fatalf("EnumerateRangeEquity() error = %v", err)
+	}
+	estimate, err := deuces.EstimateRangeEquity(hero, villains, board, nil, 100000)
+	if err != nil {
+		t.Fatalf("EstimateRangeEquity() error = %v", err)
+	}
+	if estimate.Exact || estimate.TotalIterations != 100000 {
+		t.Errorf("got Exact %v from %d iterations, want sampled from 100000", estimate.Exact, estimate.TotalIterations)
+	}
+	for i := range exact.Players {
+		if d := math.Abs(exact.Players[i].Equity - estimate.Players[i].Equity); d > 0.01 {
+			t.Errorf("player %d equity: estimate %.4f, exact %.4f", i+1, estimate.Players[i].Equity, exact.Players[i].Equity)
+		}
+	}
+}
+
+func TestRangeEquity_ChoosesMethod(t *testing.T) {
+	villains := []*deuces.Range{mustParseRange("22+,A2s+,KTo+")}
+
+	river, err := deuces.RangeEquity(mustParseRange("AK"), villains, mustNewCards("As 7d 4c 9h 2s"), nil, 20000)
+	if err != nil {
+		t.Fatalf("river: RangeEquity() error = %v", err)
+	}
+	if !river.Exact {
+		t.Error("river: expected exact enumeration")
+	}
+
+	preflop, err := deuces.RangeEquity(mustParseRange("AK"), villains, nil, nil, 20000)
+	if err != nil {
+		t.Fatalf("preflop: RangeEquity() error = %v", err)
+	}
+	if preflop.Exact || preflop.TotalIterations != 20000 {
+		t.Errorf("preflop: Exact = %v from %d, want sampled from 20000", preflop.Exact, preflop.TotalIterations)
+	}
+}
+
+func TestRangeEquity_InputValidation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		hero     *deuces.Range
+		villains []*deuces.Range
+		board    []deuces.Card
+		dead     []deuces.Card
+	}{
+		{"No villains", mustParseRange("AA"), nil, nil, nil},
+		{"Nil range", mustParseRange("AA"), []*deuces.Range{nil}, nil, nil},
+		{"Empty range", mustParseRange("AA"), []*deuces.Range{deuces.NewRange()}, nil, nil},
+		{"Board too large", mustParseRange("AA"), []*deuces.Range{mustParseRange("KK")}, mustNewCards("2c 3c 4c 5c 6c 7c"), nil},
+		{"Dead card on board", mustParseRange("AA"), []*deuces.Range{mustParseRange("KK")}, mustNewCards("2c 3c 4c"), mustNewCards("2c")},
+		{"Range blocked by board", mustParseRange("AsKs"), []*deuces.Range{mustParseRange("KK")}, mustNewCards("As 3c 4c"), nil},
+		{"Ranges conflict", mustParseRange("AsAh"), []*deuces.Range{mustParseRange("AsKs,AhKh")}, nil, nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := deuces.EnumerateRangeEquity(tc.hero, tc.villains, tc.board, tc.dead); err == nil {
+				t.Error("EnumerateRangeEquity() expected error")
+			}
+			if _, err := deuces.EstimateRangeEquity(tc.hero, tc.villains, tc.board, tc.dead, deuces.MinIterations); err == nil {
+				t.Error("EstimateRangeEquity() expected error")
+			}
+		})
+	}
+}
+
+func equityClose(a, b deuces.PlayerEquity) bool {
+	return math.Abs(a.WinProbability-b.WinProbability) < 1e-9 &&
+		math.Abs(a.TieProbability-b.TieProbability) < 1e-9 &&
+		math.Abs(a.Equity-b.Equity) < 1e-9
+}
+
+func TestEstimateWinProbabilityAgainstRanges(t *testing.T) {
+	hand := mustNewCards("As Ah")
+	board := mustNewCards("2c 3d 7h 8s Jc")
+	opts := deuces.SimulationOptions{Seed: 5}
+
+	// As in TestEnumerateRangeEquity_CardRemovalAndWeights, the hero ties the
+	// one unblocked pair of aces and beats the six kings.
+	result, err := deuces.EstimateWinProbabilityAgainstRanges(context.Background(), hand, board, nil,
+		[]*deuces.Range{mustParseRange("AA,KK")}, 50000, opts)
+	if err != nil {
+		t.Fatalf("EstimateWinProbabilityAgainstRanges() error = %v", err)
+	}
+	if math.Abs(result.TieProbability-1.0/7) > 0.01 || result.LossProbability != 0 {
+		t.Errorf("got tie %f and loss %f, want about %f and 0", result.TieProbability, result.LossProbability, 1.0/7)
+	}
+}
+
+func TestEstimateWinProbabilityAgainstRanges_MatchesRangeEquity(t *testing.T) {
+	hand := mustNewCards("Kd Kc")
+	board := mustNewCards("Ts 9s 4d")
+	villains := []*deuces.Range{mustParseRange("AQs+,TT+:0.5"), mustParseRange("JTs,98s,44")}
+
+	exact, err := deuces.EnumerateRangeEquity(mustParseRange("KdKc"), villains, board, nil)
+	if err != nil {
+		t.Fatalf("EnumerateRangeEquity() error = %v", err)
+	}
+	estimate, err := deuces.EstimateWinProbabilityAgainstRanges(context.Background(), hand, board, nil, villains, 100000, deuces.SimulationOptions{Seed: 9})
+	if err != nil {
+		t.Fatalf("EstimateWinProbabilityAgainstRanges() error = %v", err)
+	}
+	if d := math.Abs(exact.Players[0].Equity - estimate.Equity); d > 0.01 {
+		t.Errorf("equity: estimate %.4f, exact %.4f", estimate.Equity, exact.Players[0].Equity)
+	}
+	if d := math.Abs(exact.Players[0].WinProbability - estimate.WinProbability); d > 0.01 {
+		t.Errorf("win: estimate %.4f, exact %.4f", estimate.WinProbability, exact.Players[0].WinProbability)
+	}
+}
+
+func TestEstimateWinProbabilityAgainstRanges_RandomOpponents(t *testing.T) {
+	hand := mustNewCards("As Ks")
+	board := mustNewCards("Qs 8d 2c 5h")
+
+	exact, err := deuces.EnumerateWinProbability(hand, board, nil, 2)
+	if err != nil {
+		t.Fatalf("EnumerateWinProbability() error = %v", err)
+	}
+	estimate, err := deuces.EstimateWinProbabilityAgainstRanges(context.Background(), hand, board, nil, []*deuces.Range{nil, nil}, 100000, deuces.SimulationOptions{Seed: 2})
+	if err != nil {
+		t.Fatalf("EstimateWinProbabilityAgainstRanges() error = %v", err)
+	}
+	if d := math.Abs(exact.Equity - estimate.Equity); d > 0.01 {
+		t.Errorf("equity: estimate %.4f, exact %.4f", estimate.Equity, exact.Equity)
+	}
+}
+
+func TestEstimateWinProbabilityAgainstRanges_InputValidation(t *testing.T) {
+	hand := mustNewCards("As Ah")
+	for name, opponents := range map[string][]*deuces.Range{
+		"Blocked range":   {mustParseRange("AsKs,AhKh")},
+		"Empty range":     {deuces.NewRange()},
+		"Ranges conflict": {mustParseRange("KsKh"), mustParseRange("KsKh")},
+	} {
+		if _, err := deuces.EstimateWinProbabilityAgainstRanges(context.Background(), hand, nil, nil, opponents, deuces.MinIterations, deuces.SimulationOptions{}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
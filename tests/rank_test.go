@@ -0,0 +1,93 @@
+package deuces_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestHandRank_ClassMatchesEvaluator(t *testing.T) {
+	e := deuces.NewEvaluator()
+	for r := 1; r <= deuces.MaxHighCard; r++ {
+		class := deuces.HandRank(r).Class()
+		if int(class) != e.GetRankClass(r) {
+			t.Fatalf("HandRank(%d).Class() = %d, want %d", r, class, e.GetRankClass(r))
+		}
+		if class.String() != e.ClassToString(e.GetRankClass(r)) {
+			t.Fatalf("HandRank(%d).Class().String() = %q, want %q", r, class, e.ClassToString(e.GetRankClass(r)))
+		}
+	}
+	for _, r := range []deuces.HandRank{0, -1, deuces.MaxHighCard + 1} {
+		if class := r.Class(); class != 0 {
+			t.Errorf("HandRank(%d).Class() = %d, want 0", r, class)
+		}
+	}
+}
+
+func TestHandRank_Methods(t *testing.T) {
+	straight := deuces.HandRank(1609)
+	if got := straight.String(); got != "1609 (Straight)" {
+		t.Errorf("String() = %q, want %q", got, "1609 (Straight)")
+	}
+	if !straight.Beats(6066) || straight.Beats(1609) || straight.Beats(1) {
+		t.Error("Beats() does not follow lower-is-better ordering")
+	}
+	if p := deuces.HandRank(1).Percentile(); p != 1.0 {
+		t.Errorf("Percentile() of royal flush = %f, want 1.0", p)
+	}
+	if p := deuces.HandRank(deuces.MaxHighCard).Percentile(); p != 1.0/float64(deuces.MaxHighCard) {
+		t.Errorf("Percentile() of worst hand = %f, want %f", p, 1.0/float64(deuces.MaxHighCard))
+	}
+}
+
+func TestHandRank_JSON(t *testing.T) {
+	data, err := json.Marshal(deuces.HandRank(190))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != "190" {
+		t.Errorf("Marshal() = %s, want 190", data)
+	}
+
+	var r deuces.HandRank
+	if err := json.Unmarshal([]byte("190"), &r); err != nil || r != 190 {
+		t.Errorf("Unmarshal(190) = %d, %v, want 190", r, err)
+	}
+	if err := json.Unmarshal([]byte("7463"), &r); err == nil {
+		t.Error("Unmarshal(7463) expected error")
+	}
+	if _, err := json.Marshal(deuces.HandRank(0)); err == nil {
+		t.Error("Marshal(0) expected error")
+	}
+}
+
+func TestHandClass_Text(t *testing.T) {
+	classes := deuces.AllHandClasses()
+	if len(classes) != 9 || classes[0] != deuces.StraightFlush || classes[8] != deuces.HighCard {
+		t.Fatalf("AllHandClasses() = %v", classes)
+	}
+
+	for _, class := range classes {
+		text, err := class.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%d) error = %v", class, err)
+		}
+		var decoded deuces.HandClass
+		if err := decoded.UnmarshalText(text); err != nil || decoded != class {
+			t.Errorf("UnmarshalText(%q) = %d, %v, want %d", text, decoded, err, class)
+		}
+	}
+
+	data, err := json.Marshal(map[string]deuces.HandClass{"class": deuces.FullHouse})
+	if err != nil || string(data) != `{"class":"Full House"}` {
+		t.Errorf("Marshal() = %s, %v", data, err)
+	}
+	var c deuces.HandClass
+	if err := c.UnmarshalText([]byte("two pair")); err != nil || c != deuces.TwoPair {
+		t.Errorf("UnmarshalText(two pair) = %d, %v, want %d", c, err, deuces.TwoPair)
+	}
+	if err := c.UnmarshalText([]byte("Royal Straight")); err == nil {
+		t.Error("UnmarshalText(Royal Straight) expected error")
+	}
+}
@@ -0,0 +1,279 @@
+package deuces_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestShortDeck_Cards(t *testing.T) {
+	deck := deuces.GetShortDeck()
+	if len(deck) != 36 {
+		t.Fatalf("GetShortDeck() has %d cards, want 36", len(deck))
+	}
+	seen := make(map[deuces.Card]bool)
+	for _, c := range deck {
+		if c.GetRankInt() < 4 {
+			t.Errorf("short deck holds %v", c)
+		}
+		seen[c] = true
+	}
+	if len(seen) != 36 {
+		t.Errorf("short deck has %d distinct cards, want 36", len(seen))
+	}
+	if n := len(deuces.NewShortDeck().Cards); n != 36 {
+		t.Errorf("NewShortDeck() has %d cards, want 36", n)
+	}
+}
+
+func TestShortDeckEvaluator_Order(t *testing.T) {
+	evaluator := deuces.NewShortDeckEvaluator()
+	rank := func(hand string) int { return evaluator.Evaluate(mustNewCards(hand), nil) }
+
+	testCases := []struct {
+		name          string
+		better, worse string
+	}{
+		{"Flush beats full house", "6h 7h 8h 9h Jh", "Ac Ad Ah Kc Kd"},
+		{"Full house beats straight", "6c 6d 6h 7c 7d", "Tc Jd Qh Ks Ac"},
+		{"Lowest straight beats trips", "Ac 6d 7h 8s 9c", "Ac Ad Ah Kc Qd"},
+		{"Seven high straight beats the lowest", "6c 7d 8h 9s Tc", "Ac 6d 7h 8s 9c"},
+		{"Lowest straight flush beats quads", "Ah 6h 7h 8h 9h", "Ac Ad Ah As Kc"},
+		{"Ace high flush beats king high flush", "Ah Th 8h 7h 6h", "Kc Qc Jc 9c 8c"},
+		{"Aces up beats kings up", "As Ad 7c 7d 6h", "Kc Kd Qc Qd Jh"},
+		{"Ace kicker beats king kicker", "9s 9d Ac 7d 6h", "9c 9h Kc Qd Jh"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if better, worse := rank(tc.better), rank(tc.worse); better >= worse {
+				t.Errorf("rank(%s) = %d, want below rank(%s) = %d", tc.better, better, tc.worse, worse)
+			}
+		})
+	}
+
+	if got := evaluator.GetRankClass(rank("Ac 6d 7h 8s 9c")); got != int(deuces.Straight) {
+		t.Errorf("A-6-7-8-9 class = %v, want Straight", deuces.HandClass(got))
+	}
+	if got := rank("Ac 6d 7h 8s 9c"); got != deuces.MaxShortDeckStraight {
+		t.Errorf("A-6-7-8-9 rank = %d, want %d", got, deuces.MaxShortDeckStraight)
+	}
+	if got := rank("Th Jh Qh Kh Ah"); got != 1 {
+		t.Errorf("royal flush rank = %d, want 1", got)
+	}
+	if got := rank("6c 7d 8h 9s Jc"); got != deuces.MaxShortDeckHighCard {
+		t.Errorf("J-9-8-7-6 rank = %d, want %d", got, deuces.MaxShortDeckHighCard)
+	}
+}
+
+func TestShortDeckEvaluator_ClassCounts(t *testing.T) {
+	evaluator := deuces.NewShortDeckEvaluator()
+	deck := deuces.GetShortDeck()
+
+	ranks := make(map[int]bool)
+	classMax := make(map[int]int)
+	for a := 0; a < len(deck); a++ {
+		for b := a + 1; b < len(deck); b++ {
+			for c := b + 1; c < len(deck); c++ {
+				for d := c + 1; d < len(deck); d++ {
+					for e := d + 1; e < len(deck); e++ {
+						rank := evaluator.Evaluate([]deuces.Card{deck[a], deck[b], deck[c], deck[d], deck[e]}, nil)
+						ranks[rank] = true
+						class := evaluator.GetRankClass(rank)
+						classMax[class] = max(classMax[class], rank)
+					}
+				}
+			}
+		}
+	}
+
+	if len(ranks) != deuces.MaxShortDeckHighCard {
+		t.Errorf("found %d distinct ranks, want %d", len(ranks), deuces.MaxShortDeckHighCard)
+	}
+	want := map[deuces.HandClass]int{
+		deuces.StraightFlush: deuces.MaxShortDeckStraightFlush,
+		deuces.FourOfAKind:   deuces.MaxShortDeckFourOfAKind,
+		deuces.Flush:         deuces.MaxShortDeckFlush,
+		deuces.FullHouse:     deuces.MaxShortDeckFullHouse,
+		deuces.Straight:      deuces.MaxShortDeckStraight,
+		deuces.ThreeOfAKind:  deuces.MaxShortDeckThreeOfAKind,
+		deuces.TwoPair:       deuces.MaxShortDeckTwoPair,
+		deuces.Pair:          deuces.MaxShortDeckPair,
+		deuces.HighCard:      deuces.MaxShortDeckHighCard,
+	}
+	for class, max := range want {
+		if classMax[int(class)] != max {
+			t.Errorf("worst %v rank = %d, want %d", class, classMax[int(class)], max)
+		}
+	}
+}
+
+func TestEstimateEquity_ShortDeck(t *testing.T) {
+	hands := [][]deuces.Card{mustNewCards("Ah Kh"), mustNewCards("Qc Qd")}
+	board := mustNewCards("6h 9h Tc 7s")
+
+	// Every river of the short deck, counted by hand
+	evaluator := deuces.NewShortDeckEvaluator()
+	stub := &deuces.Deck{Cards: deuces.GetShortDeck()}
+	stub.Remove(hands[0]...)
+	stub.Remove(hands[1]...)
+	stub.Remove(board...)
+	var won, tied float64
+	for _, river := range stub.Cards {
+		full := append(append([]deuces.Card{}, board...), river)
+		first, second := evaluator.Evaluate(hands[0], full), evaluator.Evaluate(hands[1], full)
+		if first < second {
+			won++
+		} else if first == second {
+			tied++
+		}
+	}
+	want := (won + tied/2) / float64(len(stub.Cards))
+
+	opts := deuces.SimulationOptions{Game: deuces.ShortDeck, Seed: 7}
+	result, err := deuces.EstimateEquityWithOptions(context.Background(), hands, board, nil, 20000, opts)
+	if err != nil {
+		t.Fatalf("EstimateEquityWithOptions() error = %v", err)
+	}
+	if got := result.Players[0].Equity; math.Abs(got-want) > 0.02 {
+		t.Errorf("equity = %.4f, want %.4f", got, want)
+	}
+}
+
+func TestShortDeck_RejectsLowCards(t *testing.T) {
+	evaluator := deuces.NewShortDeckEvaluator()
+	var invalid *deuces.InvalidCardError
+	if _, err := evaluator.EvaluateChecked(mustNewCards("As Ks"), mustNewCards("Qs Js 5s")); !errors.As(err, &invalid) {
+		t.Errorf("EvaluateChecked() with a five error = %v, want *InvalidCardError", err)
+	}
+	if _, err := evaluator.BestHand(mustNewCards("As Ks"), mustNewCards("Qs Js 5s")); !errors.As(err, &invalid) {
+		t.Errorf("BestHand() with a five error = %v, want *InvalidCardError", err)
+	}
+	if rank, err := evaluator.EvaluateChecked(mustNewCards("Ah Th"), mustNewCards("8h 7h 6h")); err != nil || rank.Class() != deuces.Flush {
+		t.Errorf("EvaluateChecked() = %v, %v, want a Flush", rank, err)
+	}
+	if rank, err := evaluator.EvaluateChecked(mustNewCards("As Ks"), mustNewCards("Qs Js Ts")); err != nil || rank != 1 {
+		t.Errorf("EvaluateChecked() = %d, %v, want 1", rank, err)
+	}
+
+	opts := deuces.SimulationOptions{Game: deuces.ShortDeck}
+	ctx := context.Background()
+
+	if _, err := deuces.EstimateWinProbabilityWithOptions(ctx, mustNewCards("As 5s"), nil, nil, 1, 1000, opts); err == nil {
+		t.Error("EstimateWinProbabilityWithOptions() accepted a five")
+	}
+	hands := [][]deuces.Card{mustNewCards("As Ks"), mustNewCards("Qc Qd")}
+	if _, err := deuces.EstimateEquityWithOptions(ctx, hands, mustNewCards("2c 7d 8h"), nil, 1000, opts); err == nil {
+		t.Error("EstimateEquityWithOptions() accepted a deuce on the board")
+	}
+	if _, err := deuces.EstimateEquityWithOptions(ctx, hands, nil, nil, 1000, opts); err != nil {
+		t.Errorf("EstimateEquityWithOptions() error = %v", err)
+	}
+}
+
+func TestShortDeckEvaluator_MatchesHighHandsWithinClass(t *testing.T) {
+	high := deuces.NewEvaluator()
+	short := deuces.NewShortDeckEvaluator()
+	deck := deuces.GetShortDeck()
+
+	// The short deck rank of every standard rank whose class is unchanged,
+	// which leaves out A-6-7-8-9, a straight only in a short deck
+	shortRanks := make(map[int]int)
+	for a := 0; a < len(deck); a++ {
+		for b := a + 1; b < len(deck); b++ {
+			for c := b + 1; c < len(deck); c++ {
+				for d := c + 1; d < len(deck); d++ {
+					for e := d + 1; e < len(deck); e++ {
+						cards := []deuces.Card{deck[a], deck[b], deck[c], deck[d], deck[e]}
+						highRank, shortRank := high.Evaluate(cards, nil), short.Evaluate(cards, nil)
+						if high.GetRankClass(highRank) != short.GetRankClass(shortRank) {
+							continue
+						}
+						if prev, ok := shortRanks[highRank]; ok && prev != shortRank {
+							t.Fatalf("%v: high rank %d has short deck ranks %d and %d", cards, highRank, prev, shortRank)
+						}
+						shortRanks[highRank] = shortRank
+					}
+				}
+			}
+		}
+	}
+
+	highRanks := make([]int, 0, len(shortRanks))
+	for rank := range shortRanks {
+		highRanks = append(highRanks, rank)
+	}
+	sort.Ints(highRanks)
+	for i := 1; i < len(highRanks); i++ {
+		prev, next := highRanks[i-1], highRanks[i]
+		if high.GetRankClass(prev) != high.GetRankClass(next) {
+			continue
+		}
+		if shortRanks[prev] >= shortRanks[next] {
+			t.Fatalf("high ranks %d < %d, short deck ranks %d >= %d", prev, next, shortRanks[prev], shortRanks[next])
+		}
+	}
+}
+
+func TestShortDeckEvaluator_BestHand(t *testing.T) {
+	testCases := []struct {
+		hand, board string
+		cards       string
+		description string
+	}{
+		{"Ah Th", "8h 7h 6h Kc Kd", "Ah Th 8h 7h 6h", "Flush, A-T-8-7-6"},
+		{"Kc Kd", "Ks 7h 7c 9d 6c", "Ks Kd Kc 7h 7c", "Full House, Kings full of Sevens"},
+		{"As 6d", "7c 8h 9s Kd Qc", "9s 8h 7c 6d As", "Straight, Nine high"},
+		{"Ah 6h", "7h 8h 9h Kd Qc", "9h 8h 7h 6h Ah", "Straight Flush, Nine high"},
+	}
+	e := deuces.NewShortDeckEvaluator()
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			hand, board := mustNewCards(tc.hand), mustNewCards(tc.board)
+			made, err := e.BestHand(hand, board)
+			if err != nil {
+				t.Fatalf("BestHand() error = %v", err)
+			}
+			if got := made.Description; got != tc.description {
+				t.Errorf("Description = %q, want %q", got, tc.description)
+			}
+			want := mustNewCards(tc.cards)
+			for i := range want {
+				if made.Cards[i] != want[i] {
+					t.Errorf("Cards = %v, want %v", made.Cards, want)
+					break
+				}
+			}
+			if int(made.Rank) != e.Evaluate(hand, board) {
+				t.Errorf("Rank = %d, want %d", made.Rank, e.Evaluate(hand, board))
+			}
+		})
+	}
+}
+
+func TestShortDeckRank(t *testing.T) {
+	flush := deuces.ShortDeckRank(deuces.MaxShortDeckFourOfAKind + 1)
+	if got := flush.String(); got != "79 (Flush)" {
+		t.Errorf("String() = %q, want %q", got, "79 (Flush)")
+	}
+	if got := deuces.ShortDeckRank(deuces.MaxShortDeckFlush + 1).Class(); got != deuces.FullHouse {
+		t.Errorf("Class() = %v, want Full House", got)
+	}
+	if !flush.Beats(deuces.ShortDeckRank(deuces.MaxShortDeckFullHouse)) {
+		t.Error("a flush should beat a full house")
+	}
+	if p := deuces.ShortDeckRank(1).Percentile(); p != 1.0 {
+		t.Errorf("Percentile() of a royal flush = %v, want 1", p)
+	}
+	if p := deuces.ShortDeckRank(deuces.MaxShortDeckHighCard).Percentile(); p != 1.0/deuces.MaxShortDeckHighCard {
+		t.Errorf("Percentile() of the worst hand = %v, want %v", p, 1.0/deuces.MaxShortDeckHighCard)
+	}
+	for _, r := range []deuces.ShortDeckRank{0, deuces.MaxShortDeckHighCard + 1} {
+		if r.IsValid() || r.Class() != 0 {
+			t.Errorf("ShortDeckRank(%d) is valid with class %v", int(r), r.Class())
+		}
+	}
+}
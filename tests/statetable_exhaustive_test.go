@@ -0,0 +1,75 @@
+//go:build exhaustive
+
+package deuces_test
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+// TestStateTableEvaluator_AllSevenCardHands compares the state table with the
+// evaluator on all 133,784,560 seven card hands. Run it with
+//
+//	go test -tags exhaustive -run AllSevenCardHands -timeout 30m ./tests/
+func TestStateTableEvaluator_AllSevenCardHands(t *testing.T) {
+	want := deuces.NewEvaluator()
+	got := deuces.NewStateTableEvaluator()
+	deck := deuces.GetFullDeck()
+
+	var hands, mismatches atomic.Int64
+	firsts := make(chan int, 52)
+	for a := 0; a < 52; a++ {
+		firsts <- a
+	}
+	close(firsts)
+
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cards := make([]deuces.Card, 7)
+			for a := range firsts {
+				var n int64
+				cards[0] = deck[a]
+				for b := a + 1; b < 52; b++ {
+					cards[1] = deck[b]
+					for c := b + 1; c < 52; c++ {
+						cards[2] = deck[c]
+						for d := c + 1; d < 52; d++ {
+							cards[3] = deck[d]
+							for e := d + 1; e < 52; e++ {
+								cards[4] = deck[e]
+								for f := e + 1; f < 52; f++ {
+									cards[5] = deck[f]
+									for g := f + 1; g < 52; g++ {
+										cards[6] = deck[g]
+										n++
+										if got.Evaluate(cards[:2], cards[2:]) != want.Evaluate(cards[:2], cards[2:]) {
+											if mismatches.Add(1) <= 10 {
+												t.Errorf("mismatch for %v", cards)
+											}
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+				hands.Add(n)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if hands.Load() != 133784560 {
+		t.Errorf("evaluated %d hands, want 133784560", hands.Load())
+	}
+	if m := mismatches.Load(); m != 0 {
+		t.Errorf("%d hands differ from Evaluator.Evaluate", m)
+	}
+}
@@ -0,0 +1,127 @@
+package deuces_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestStateTableEvaluator_MatchesEvaluator(t *testing.T) {
+	if testing.Short() {
+		t.Skip("generating the state table takes several seconds")
+	}
+	want := deuces.NewEvaluator()
+	got := deuces.NewStateTableEvaluator()
+
+	for size := 5; size <= 7; size++ {
+		for _, cards := range randomHands(20000, size) {
+			if g, w := got.Evaluate(cards[:2], cards[2:]), want.Evaluate(cards[:2], cards[2:]); g != w {
+				t.Fatalf("Evaluate(%v) = %d, want %d", cards, g, w)
+			}
+		}
+	}
+
+	// Duplicate and invalid cards have no state
+	for _, cards := range [][]deuces.Card{
+		mustNewCards("As As Kd Qh Jc"),
+		mustNewCards("As Kd Qh Jc 2c 9d 2c"),
+		{mustNewCard("As"), mustNewCard("Kd"), mustNewCard("Qh"), mustNewCard("Jc"), 0},
+	} {
+		if g := got.Evaluate(cards[:2], cards[2:]); g != -1 {
+			t.Errorf("Evaluate(%v) = %d, want -1", cards, g)
+		}
+	}
+}
+
+func TestStateTableEvaluator_SaveAndLoad(t *testing.T) {
+	if testing.Short() {
+		t.Skip("generating the state table takes several seconds")
+	}
+	path := filepath.Join(t.TempDir(), "states.dat")
+	if err := deuces.NewStateTableEvaluator().SaveStateTable(path); err != nil {
+		t.Fatalf("SaveStateTable() error = %v", err)
+	}
+
+	loaded, err := deuces.LoadStateTableEvaluator(path)
+	if err != nil {
+		t.Fatalf("LoadStateTableEvaluator() error = %v", err)
+	}
+	want := deuces.NewEvaluator()
+	for _, cards := range randomHands(20000, 7) {
+		if g, w := loaded.Evaluate(cards[:2], cards[2:]), want.Evaluate(cards[:2], cards[2:]); g != w {
+			t.Fatalf("Evaluate(%v) = %d, want %d", cards, g, w)
+		}
+	}
+}
+
+func TestLoadStateTableEvaluator_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "states.dat")
+	if err := os.WriteFile(path, []byte("not a state table"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := deuces.LoadStateTableEvaluator(path); err == nil {
+		t.Error("LoadStateTableEvaluator() expected error for invalid file")
+	}
+}
+
+func TestReadStateTable_CorruptEntries(t *testing.T) {
+	table := func(entries []int32) []byte {
+		data := binary.LittleEndian.AppendUint64([]byte("DEUCES2P"), uint64(len(entries)))
+		for _, v := range entries {
+			data = binary.LittleEndian.AppendUint32(data, uint32(v))
+		}
+		return data
+	}
+	rows := func(n int, value int32) []int32 {
+		entries := make([]int32, 53*n)
+		for i := 53; i < len(entries); i++ {
+			entries[i] = value
+		}
+		return entries
+	}
+
+	testCases := []struct {
+		name    string
+		entries []int32
+	}{
+		{"Offset past the table", rows(2, 1000000)},
+		{"Negative entry", rows(2, -53)},
+		{"Offset inside a row", rows(2, 54)},
+		{"Loop back to an earlier state", rows(2, 53)},
+		{"Used empty row", append([]int32{0, 7}, rows(2, 0)[2:]...)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := deuces.ReadStateTable(bytes.NewReader(table(tc.entries))); err == nil {
+				t.Error("ReadStateTable() expected error")
+			}
+		})
+	}
+
+	// Every state leads to the empty row, so no hand has a rank, but it
+	// cannot read past the table either
+	if _, err := deuces.ReadStateTable(bytes.NewReader(table(rows(2, 0)))); err != nil {
+		t.Errorf("ReadStateTable() error = %v", err)
+	}
+}
+
+func TestEvaluator_SaveStateTableWithoutTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "states.dat")
+	if err := deuces.NewEvaluator().SaveStateTable(path); err == nil {
+		t.Error("SaveStateTable() expected error for evaluator without a state table")
+	}
+}
+
+func BenchmarkStateTableEvaluator_SevenCards(b *testing.B) {
+	e := deuces.NewStateTableEvaluator()
+	hands := randomHands(4096, 7)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hand := hands[i&4095]
+		e.Evaluate(hand[:2], hand[2:])
+	}
+}
@@ -0,0 +1,127 @@
+package deuces_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func TestAnalyzeHandStrength_Flop(t *testing.T) {
+	// The worked example of Billings et al.: AdQc on 3h4cJh.
+	result, err := deuces.AnalyzeHandStrength(mustNewCards("Ad Qc"), mustNewCards("3h 4c Jh"), nil, 1)
+	if err != nil {
+		t.Fatalf("AnalyzeHandStrength() error = %v", err)
+	}
+	if !result.Exact || result.TotalIterations != 1081*990 {
+		t.Errorf("got Exact %v from %d runouts, want exact from %d", result.Exact, result.TotalIterations, 1081*990)
+	}
+	for _, m := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"HS", result.HS, 0.585},
+		{"PPot", result.PPot, 0.208},
+		{"NPot", result.NPot, 0.274},
+	} {
+		if math.Abs(m.got-m.want) > 0.001 {
+			t.Errorf("%s = %.4f, want %.3f", m.name, m.got, m.want)
+		}
+	}
+	if want := result.HS*(1-result.NPot) + (1-result.HS)*result.PPot; math.Abs(result.EHS-want) > 1e-12 {
+		t.Errorf("EHS = %f, want %f", result.EHS, want)
+	}
+}
+
+func TestAnalyzeHandStrength_Opponents(t *testing.T) {
+	hand, board := mustNewCards("Ad Qc"), mustNewCards("3h 4c Jh 9s")
+
+	one, err := deuces.AnalyzeHandStrength(hand, board, nil, 1)
+	if err != nil {
+		t.Fatalf("AnalyzeHandStrength() error = %v", err)
+	}
+	three, err := deuces.AnalyzeHandStrength(hand, board, nil, 3)
+	if err != nil {
+		t.Fatalf("AnalyzeHandStrength() error = %v", err)
+	}
+	if one.HS != three.HS || one.PPot != three.PPot || one.NPot != three.NPot {
+		t.Errorf("HS and potentials depend on the number of opponents: %v and %v", one, three)
+	}
+	hs3 := math.Pow(three.HS, 3)
+	if want := hs3*(1-three.NPot) + (1-hs3)*three.PPot; math.Abs(three.EHS-want) > 1e-12 || three.EHS >= one.EHS {
+		t.Errorf("EHS against three = %f, want %f and below %f", three.EHS, want, one.EHS)
+	}
+}
+
+func TestAnalyzeHandStrength_River(t *testing.T) {
+	// The nut straight loses only to nothing and ties other broadway hands.
+	result, err := deuces.AnalyzeHandStrength(mustNewCards("As Kd"), mustNewCards("Qh Jc Ts 4d 2c"), nil, 2)
+	if err != nil {
+		t.Fatalf("AnalyzeHandStrength() error = %v", err)
+	}
+	if result.PPot != 0 || result.NPot != 0 {
+		t.Errorf("river potentials = %f and %f, want 0", result.PPot, result.NPot)
+	}
+	if want := math.Pow(result.HS, 2); math.Abs(result.EHS-want) > 1e-12 {
+		t.Errorf("EHS = %f, want HS squared %f", result.EHS, want)
+	}
+	if result.HS <= 0.9 || result.HS >= 1 {
+		t.Errorf("HS = %f, want a tied nut hand just below 1", result.HS)
+	}
+}
+
+func TestEstimateHandStrength_MatchesAnalysis(t *testing.T) {
+	hand, board := mustNewCards("Ad Qc"), mustNewCards("3h 4c Jh")
+	dead := mustNewCards("2h")
+
+	exact, err := deuces.AnalyzeHandStrength(hand, board, dead, 2)
+	if err != nil {
+		t.Fatalf("AnalyzeHandStrength() error = %v", err)
+	}
+	estimate, err := deuces.EstimateHandStrength(context.Background(), hand, board, dead, 2, 200000, deuces.SimulationOptions{Seed: 4})
+	if err != nil {
+		t.Fatalf("EstimateHandStrength() error = %v", err)
+	}
+	if estimate.Exact || estimate.TotalIterations != 200000 {
+		t.Errorf("got Exact %v from %d iterations, want sampled from 200000", estimate.Exact, estimate.TotalIterations)
+	}
+	for _, m := range []struct {
+		name            string
+		estimate, exact float64
+	}{
+		{"HS", estimate.HS, exact.HS},
+		{"PPot", estimate.PPot, exact.PPot},
+		{"NPot", estimate.NPot, exact.NPot},
+		{"EHS", estimate.EHS, exact.EHS},
+	} {
+		if math.Abs(m.estimate-m.exact) > 0.01 {
+			t.Errorf("%s: estimate %.4f, exact %.4f", m.name, m.estimate, m.exact)
+		}
+	}
+}
+
+func TestHandStrength_InputValidation(t *testing.T) {
+	hand := mustNewCards("Ad Qc")
+	testCases := []struct {
+		name         string
+		board        []deuces.Card
+		dead         []deuces.Card
+		numOpponents int
+	}{
+		{"Preflop", nil, nil, 1},
+		{"No opponents", mustNewCards("3h 4c Jh"), nil, 0},
+		{"Too many opponents", mustNewCards("3h 4c Jh"), nil, deuces.MaxOpponents + 1},
+		{"Dead card on board", mustNewCards("3h 4c Jh"), mustNewCards("Jh"), 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := deuces.AnalyzeHandStrength(hand, tc.board, tc.dead, tc.numOpponents); err == nil {
+				t.Error("AnalyzeHandStrength() expected error")
+			}
+			if _, err := deuces.EstimateHandStrength(context.Background(), hand, tc.board, tc.dead, tc.numOpponents, deuces.MinIterations, deuces.SimulationOptions{}); err == nil {
+				t.Error("EstimateHandStrength() expected error")
+			}
+		})
+	}
+}
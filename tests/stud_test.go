@@ -0,0 +1,152 @@
+package deuces_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/gregory-chatelier/go-deuces"
+)
+
+func studHand(down, up string) deuces.StudHand {
+	return deuces.StudHand{Down: mustNewCards(down), Up: mustNewCards(up)}
+}
+
+// enumerateSixthStreet returns the first player's equity over every seventh
+// street card the two players can be dealt, given the rank of a seven card
+// hand, lower being better.
+func enumerateSixthStreet(hands []deuces.StudHand, dead []deuces.Card, rank func([]deuces.Card) int) float64 {
+	stub := &deuces.Deck{Cards: deuces.GetFullDeck()}
+	for _, hand := range hands {
+		stub.Remove(hand.Down...)
+		stub.Remove(hand.Up...)
+	}
+	stub.Remove(dead...)
+
+	seven := func(hand deuces.StudHand, card deuces.Card) []deuces.Card {
+		return append(append(append([]deuces.Card{}, hand.Down...), hand.Up...), card)
+	}
+	var share float64
+	deals := 0
+	for i, first := range stub.Cards {
+		for j, second := range stub.Cards {
+			if i == j {
+				continue
+			}
+			a, b := rank(seven(hands[0], first)), rank(seven(hands[1], second))
+			if a < b {
+				share++
+			} else if a == b {
+				share += 0.5
+			}
+			deals++
+		}
+	}
+	return share / float64(deals)
+}
+
+func TestEstimateStudEquity_MatchesEnumeration(t *testing.T) {
+	hands := []deuces.StudHand{
+		studHand("Ah Kh", "Qh 2h 9c 9d"),
+		studHand("Tc Ts", "Td 5c 4s 3d"),
+	}
+	dead := mustNewCards("7h 6h")
+
+	evaluator := deuces.NewEvaluator()
+	razz := deuces.NewAceToFiveEvaluator()
+	testCases := []struct {
+		game deuces.Game
+		rank func([]deuces.Card) int
+	}{
+		{deuces.Stud, func(cards []deuces.Card) int { return evaluator.Evaluate(cards, nil) }},
+		{deuces.Razz, func(cards []deuces.Card) int { return razz.Evaluate(cards, nil) }},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.game.String(), func(t *testing.T) {
+			want := enumerateSixthStreet(hands, dead, tc.rank)
+			opts := deuces.SimulationOptions{Game: tc.game, Seed: 3}
+			result, err := deuces.EstimateStudEquity(context.Background(), hands, dead, 20000, opts)
+			if err != nil {
+				t.Fatalf("EstimateStudEquity() error = %v", err)
+			}
+			if got := result.Players[0].Equity; math.Abs(got-want) > 0.02 {
+				t.Errorf("equity = %.4f, want %.4f", got, want)
+			}
+			if sum := result.Players[0].Equity + result.Players[1].Equity; math.Abs(sum-1) > 1e-9 {
+				t.Errorf("equities sum to %v, want 1", sum)
+			}
+		})
+	}
+}
+
+func TestEstimateStudEquity_SeventhStreet(t *testing.T) {
+	ctx := context.Background()
+
+	// Complete hands leave nothing to deal, so every showdown is the same
+	hands := []deuces.StudHand{
+		studHand("Ah Kh 9h", "Qh 2h 9d Js"), // flush, no low
+		studHand("Ac 2d 8s", "3c 4d 6c Kd"), // 6-4-3-2-A low
+		studHand("Kc Ks 8d", "Qd Qs 7c Jc"), // two pair, no low
+	}
+	testCases := []struct {
+		game deuces.Game
+		want []float64
+	}{
+		{deuces.Stud, []float64{1, 0, 0}},
+		{deuces.Razz, []float64{0, 1, 0}},
+		{deuces.StudHiLo, []float64{0.5, 0.5, 0}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.game.String(), func(t *testing.T) {
+			opts := deuces.SimulationOptions{Game: tc.game, Seed: 1}
+			result, err := deuces.EstimateStudEquity(ctx, hands, nil, 1000, opts)
+			if err != nil {
+				t.Fatalf("EstimateStudEquity() error = %v", err)
+			}
+			for p, want := range tc.want {
+				if got := result.Players[p].Equity; got != want {
+					t.Errorf("player %d equity = %v, want %v", p+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEstimateStudEquity_InvalidInput(t *testing.T) {
+	ctx := context.Background()
+	opts := deuces.SimulationOptions{Game: deuces.Stud}
+	thirdStreet := []deuces.StudHand{studHand("Ah Kh", "Qh"), studHand("Tc Ts", "Td")}
+
+	eightPlayers := make([]deuces.StudHand, 8)
+	deck := deuces.GetFullDeck()
+	for p := range eightPlayers {
+		eightPlayers[p] = deuces.StudHand{Down: deck[3*p : 3*p+2], Up: deck[3*p+2 : 3*p+3]}
+	}
+
+	testCases := []struct {
+		name  string
+		hands []deuces.StudHand
+		dead  []deuces.Card
+		opts  deuces.SimulationOptions
+	}{
+		{"Not a stud game", thirdStreet, nil, deuces.SimulationOptions{}},
+		{"One player", thirdStreet[:1], nil, opts},
+		{"No up card", []deuces.StudHand{studHand("Ah Kh", ""), studHand("Tc Ts", "Td")}, nil, opts},
+		{"Down card before seventh street", []deuces.StudHand{studHand("Ah Kh Ks", "Qh"), studHand("Tc Ts", "Td")}, nil, opts},
+		{"Different streets", []deuces.StudHand{studHand("Ah Kh", "Qh Js"), studHand("Tc Ts", "Td")}, nil, opts},
+		{"Dead card held", thirdStreet, mustNewCards("Qh"), opts},
+		{"Deck runs out", eightPlayers, nil, opts},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := deuces.EstimateStudEquity(ctx, tc.hands, tc.dead, 1000, tc.opts); err == nil {
+				t.Error("EstimateStudEquity() error = nil, want an error")
+			}
+		})
+	}
+
+	hands := [][]deuces.Card{mustNewCards("Ah Kh"), mustNewCards("Tc Ts")}
+	if _, err := deuces.EstimateEquityWithOptions(ctx, hands, nil, nil, 1000, opts); err == nil {
+		t.Error("EstimateEquityWithOptions() accepted a stud game")
+	}
+}